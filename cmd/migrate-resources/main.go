@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command migrate-resources reads a v1beta1 TaskRun YAML file that
+// references PipelineResources and prints the equivalent v1 TaskRun that
+// uses workspaces, params and results instead.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/migration"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: migrate-resources <taskrun.yaml>")
+	}
+
+	in, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("reading %s: %v", flag.Arg(0), err)
+	}
+
+	tr := &v1beta1.TaskRun{}
+	if err := yaml.Unmarshal(in, tr); err != nil {
+		log.Fatalf("parsing %s: %v", flag.Arg(0), err)
+	}
+
+	out, warnings, err := migration.Convert(context.Background(), tr)
+	if err != nil {
+		log.Fatalf("converting %s: %v", flag.Arg(0), err)
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+
+	outYAML, err := yaml.Marshal(out)
+	if err != nil {
+		log.Fatalf("marshalling converted TaskRun: %v", err)
+	}
+	os.Stdout.Write(outYAML)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+// bundleResolverParamNames are the only param names the in-tree bundles
+// resolver reads off a ResolverRef. A v1beta1 conversion that stashes a
+// Bundle/Name/Kind fidelity annotation still emits params from this same
+// list (see pkg/apis/pipeline/v1beta1's bundleFidelity), so this list
+// doubles as the conversion's contract with the resolver.
+var bundleResolverParamNames = map[string]bool{
+	"bundle":         true,
+	"name":           true,
+	"kind":           true,
+	"tag":            true,
+	"digest":         true,
+	"serviceAccount": true,
+}
+
+// ValidateBundleResolverParams rejects a ResolverRef claiming
+// Resolver: "bundles" with a param name the bundles resolver doesn't
+// recognize, so a typo or unsupported field fails at admission instead of
+// being silently dropped by the resolver at run time. It is a no-op for
+// any other resolver.
+func ValidateBundleResolverParams(resolver string, params []Param) (errs *apis.FieldError) {
+	if resolver != "bundles" {
+		return
+	}
+	for i, p := range params {
+		if !bundleResolverParamNames[p.Name] {
+			errs = errs.Also(apis.ErrInvalidValue(p.Name, "name", "not a param the bundles resolver accepts").ViaIndex(i))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func TestValidateBundleResolverParams(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		resolver string
+		params   []Param
+		wantErr  bool
+	}{{
+		name:     "not the bundles resolver is ignored",
+		resolver: "git",
+		params:   []Param{{Name: "nonsense"}},
+	}, {
+		name:     "every recognized param name",
+		resolver: "bundles",
+		params: []Param{
+			{Name: "bundle"}, {Name: "name"}, {Name: "kind"},
+			{Name: "tag"}, {Name: "digest"}, {Name: "serviceAccount"},
+		},
+	}, {
+		name:     "unknown param name is rejected",
+		resolver: "bundles",
+		params:   []Param{{Name: "bundle"}, {Name: "repository"}},
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBundleResolverParams(tc.resolver, tc.params)
+			if tc.wantErr && err == nil {
+				t.Errorf("ValidateBundleResolverParams() expected an error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("ValidateBundleResolverParams() returned error: %v", err)
+			}
+		})
+	}
+}
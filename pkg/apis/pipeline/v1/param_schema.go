@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"knative.dev/pkg/apis"
+)
+
+// ValidateObjectValue checks an object param's value (a ParamValue's
+// ObjectVal) against the schema declared across properties: every
+// declared key whose PropertySpec doesn't explicitly set Required to
+// false must be present, present values must satisfy their property's
+// format constraints, and, if additionalPropertiesAllowed is false, keys
+// outside properties are rejected.
+func ValidateObjectValue(fieldName string, properties map[string]PropertySpec, additionalPropertiesAllowed bool, value map[string]string) (errs *apis.FieldError) {
+	for key, ps := range properties {
+		v, present := value[key]
+		if !present {
+			if ps.Required != nil && !*ps.Required {
+				continue
+			}
+			errs = errs.Also(apis.ErrMissingField(key).ViaField(fieldName))
+			continue
+		}
+		errs = errs.Also(validatePropertyValue(fieldName, key, ps, v))
+	}
+	if !additionalPropertiesAllowed {
+		for key := range value {
+			if _, declared := properties[key]; !declared {
+				errs = errs.Also(apis.ErrInvalidValue(key, fieldName, "additionalProperties is false and this key is not declared"))
+			}
+		}
+	}
+	return errs
+}
+
+func validatePropertyValue(fieldName, key string, ps PropertySpec, v string) (errs *apis.FieldError) {
+	if len(ps.Enum) > 0 && !stringInSlice(v, ps.Enum) {
+		errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must be one of %v", ps.Enum)).ViaField(fieldName))
+	}
+	if ps.Pattern != "" {
+		re, err := regexp.Compile(ps.Pattern)
+		if err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("invalid pattern %q: %v", ps.Pattern, err), key).ViaField(fieldName))
+		} else if !re.MatchString(v) {
+			errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must match pattern %q", ps.Pattern)).ViaField(fieldName))
+		}
+	}
+	if ps.MinLength != nil && int64(len(v)) < *ps.MinLength {
+		errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must be at least %d characters", *ps.MinLength)).ViaField(fieldName))
+	}
+	if ps.MaxLength != nil && int64(len(v)) > *ps.MaxLength {
+		errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must be at most %d characters", *ps.MaxLength)).ViaField(fieldName))
+	}
+	if ps.Minimum != nil || ps.Maximum != nil {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, key, "must be a number").ViaField(fieldName))
+			return errs
+		}
+		if ps.Minimum != nil && n < *ps.Minimum {
+			errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must be >= %v", *ps.Minimum)).ViaField(fieldName))
+		}
+		if ps.Maximum != nil && n > *ps.Maximum {
+			errs = errs.Also(apis.ErrInvalidValue(v, key, fmt.Sprintf("must be <= %v", *ps.Maximum)).ViaField(fieldName))
+		}
+	}
+	return errs
+}
+
+func stringInSlice(v string, list []string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
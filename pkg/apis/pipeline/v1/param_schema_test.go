@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func float64Ptr(f float64) *float64 { return &f }
+func int64Ptr(i int64) *int64       { return &i }
+func boolPtr(b bool) *bool          { return &b }
+
+func TestValidateObjectValue_Valid(t *testing.T) {
+	properties := map[string]PropertySpec{
+		"name": {Type: ParamTypeString, MinLength: int64Ptr(1), MaxLength: int64Ptr(20)},
+		"tag":  {Type: ParamTypeString, Pattern: `^[a-zA-Z0-9._-]+$`},
+		"env":  {Type: ParamTypeString, Enum: []string{"dev", "prod"}},
+		"port": {Type: ParamTypeString, Minimum: float64Ptr(1), Maximum: float64Ptr(65535)},
+	}
+	value := map[string]string{"name": "my-image", "tag": "v1.0.0", "env": "prod", "port": "8080"}
+	if err := ValidateObjectValue("image", properties, true, value); err != nil {
+		t.Errorf("ValidateObjectValue() = %v, want no error", err)
+	}
+}
+
+func TestValidateObjectValue_OptionalKey(t *testing.T) {
+	properties := map[string]PropertySpec{
+		"name": {Type: ParamTypeString},
+		"tag":  {Type: ParamTypeString, Required: boolPtr(false)},
+	}
+	if err := ValidateObjectValue("image", properties, true, map[string]string{"name": "my-image"}); err != nil {
+		t.Errorf("ValidateObjectValue() = %v, want no error when an optional key is absent", err)
+	}
+}
+
+func TestValidateObjectValue_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name                        string
+		properties                  map[string]PropertySpec
+		additionalPropertiesAllowed bool
+		value                       map[string]string
+	}{{
+		name:                        "missing required key",
+		properties:                  map[string]PropertySpec{"name": {Type: ParamTypeString}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{},
+	}, {
+		name:                        "pattern mismatch",
+		properties:                  map[string]PropertySpec{"tag": {Type: ParamTypeString, Pattern: `^[a-zA-Z0-9._-]+$`}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"tag": "not a valid tag!"},
+	}, {
+		name:                        "enum mismatch",
+		properties:                  map[string]PropertySpec{"env": {Type: ParamTypeString, Enum: []string{"dev", "prod"}}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"env": "staging"},
+	}, {
+		name:                        "below minimum",
+		properties:                  map[string]PropertySpec{"port": {Type: ParamTypeString, Minimum: float64Ptr(1)}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"port": "0"},
+	}, {
+		name:                        "above maximum",
+		properties:                  map[string]PropertySpec{"port": {Type: ParamTypeString, Maximum: float64Ptr(65535)}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"port": "70000"},
+	}, {
+		name:                        "not a number",
+		properties:                  map[string]PropertySpec{"port": {Type: ParamTypeString, Maximum: float64Ptr(65535)}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"port": "abc"},
+	}, {
+		name:                        "too short",
+		properties:                  map[string]PropertySpec{"name": {Type: ParamTypeString, MinLength: int64Ptr(5)}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"name": "ab"},
+	}, {
+		name:                        "too long",
+		properties:                  map[string]PropertySpec{"name": {Type: ParamTypeString, MaxLength: int64Ptr(2)}},
+		additionalPropertiesAllowed: true,
+		value:                       map[string]string{"name": "abc"},
+	}, {
+		name:                        "additional properties disallowed",
+		properties:                  map[string]PropertySpec{"name": {Type: ParamTypeString}},
+		additionalPropertiesAllowed: false,
+		value:                       map[string]string{"name": "my-image", "extra_key": "oops"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateObjectValue("image", tc.properties, tc.additionalPropertiesAllowed, tc.value); err == nil {
+				t.Error("ValidateObjectValue() expected an error, got none")
+			}
+		})
+	}
+}
+
+// TestValidateObjectValue_AdditionalPropertiesIsDeterministic examines
+// that additionalPropertiesAllowed is read from the object-level
+// parameter, not derived from whichever property a map happens to
+// range over first - two properties can't disagree because there's only
+// one flag to ask.
+func TestValidateObjectValue_AdditionalPropertiesIsDeterministic(t *testing.T) {
+	properties := map[string]PropertySpec{
+		"a": {Type: ParamTypeString},
+		"b": {Type: ParamTypeString},
+	}
+	value := map[string]string{"a": "1", "b": "2", "extra": "oops"}
+	for i := 0; i < 20; i++ {
+		if err := ValidateObjectValue("image", properties, false, value); err == nil {
+			t.Fatalf("iteration %d: ValidateObjectValue() expected an error for an undeclared key, got none", i)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// ParamType declares the type a Param/ParamSpec/ParamValue carries.
+type ParamType string
+
+// Valid ParamType values.
+const (
+	ParamTypeString ParamType = "string"
+	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
+)
+
+// ParamValue holds a param's actual value, as one of the three types
+// ParamType enumerates. Only the field matching Type is meaningful.
+type ParamValue struct {
+	Type      ParamType
+	StringVal string
+	ArrayVal  []string
+	ObjectVal map[string]string
+}
+
+// NewStructuredValues returns a string-typed ParamValue for a single
+// value, or an array-typed one for more than one.
+func NewStructuredValues(values ...string) *ParamValue {
+	if len(values) == 1 {
+		return &ParamValue{Type: ParamTypeString, StringVal: values[0]}
+	}
+	return &ParamValue{Type: ParamTypeArray, ArrayVal: values}
+}
+
+// NewObject returns an object-typed ParamValue wrapping pairs.
+func NewObject(pairs map[string]string) *ParamValue {
+	return &ParamValue{Type: ParamTypeObject, ObjectVal: pairs}
+}
+
+// Param is a name/value pair passed to a Task or Pipeline.
+type Param struct {
+	Name  string
+	Value ParamValue
+}
+
+// ParamValidationRule is a single CEL expression a param's resolved value
+// must satisfy, evaluated with self bound to that value (see
+// pkg/reconciler/pipelinerun/resources/validate_param_cel.go).
+type ParamValidationRule struct {
+	// Expression is a CEL expression evaluating to bool.
+	Expression string
+	// Message is shown instead of a generic failure message when set.
+	Message string
+}
+
+// PropertySpec describes the shape and constraints of one key of an
+// object-typed param. Beyond Type, the JSON Schema Draft-07 subset below
+// lets object param authors reject malformed values (e.g. a tag that
+// isn't a valid image reference component) without hand-written
+// validation steps; CEL extends that with an arbitrary boolean
+// expression evaluated against the key's string value.
+type PropertySpec struct {
+	Type ParamType
+
+	// Minimum and Maximum bound a property holding a number.
+	Minimum *float64
+	Maximum *float64
+	// MinLength and MaxLength bound a property holding a string.
+	MinLength *int64
+	MaxLength *int64
+	// Pattern is a regular expression, in Go regexp (RE2) syntax, the
+	// value must match.
+	Pattern string
+	// Enum is the closed set of values the property may take.
+	Enum []string
+	// Required, set to false, makes this key optional; a nil or true
+	// Required is the pre-existing object param contract: every key
+	// declared in a ParamSpec's Properties must be present.
+	Required *bool
+	// CEL is a single CEL expression evaluated with self bound to this
+	// key's string value.
+	CEL string
+}
+
+// ParamSpec declares one param a Task or Pipeline accepts.
+type ParamSpec struct {
+	Name        string
+	Type        ParamType
+	Description string
+	// Properties declares the schema of an object-typed param's keys.
+	Properties map[string]PropertySpec
+	Default    *ParamValue
+	// AdditionalProperties, set to false, rejects ObjectVal keys this
+	// param's Properties doesn't declare. It's an object-level setting -
+	// unlike Properties' per-key constraints - so it lives here rather
+	// than on PropertySpec, where reading it off an arbitrary property
+	// would be ambiguous once two properties disagreed.
+	AdditionalProperties *bool
+	// Validation is evaluated, in order, against the param's resolved
+	// value with self bound to it.
+	Validation []ParamValidationRule
+}
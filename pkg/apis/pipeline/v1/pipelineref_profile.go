@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	"knative.dev/pkg/apis"
+)
+
+// ResolverStrategy selects how a PipelineRef/TaskRef's Refs profile is
+// attempted when more than one ResolverRef is given.
+type ResolverStrategy string
+
+const (
+	// ResolverStrategySingle is the default: Refs must contain exactly one
+	// entry, equivalent to setting Resolver/Params directly.
+	ResolverStrategySingle ResolverStrategy = "single"
+	// ResolverStrategyFallback attempts each entry in order, moving to the
+	// next only if the previous one fails to resolve.
+	ResolverStrategyFallback ResolverStrategy = "fallback"
+	// ResolverStrategyRace accepts any number of entries.
+	// AttemptResolverProfile tries them one at a time, in order, and uses
+	// the first that resolves; the entries are not attempted concurrently.
+	ResolverStrategyRace ResolverStrategy = "race"
+)
+
+// validateResolverProfile validates the Refs/Strategy profile fields on a
+// PipelineRef or TaskRef: exactly one of Name, a single Resolver, or a Refs
+// profile may be set, every entry of Refs must independently validate, and
+// Strategy must be one of the known values.
+func validateResolverProfile(ctx context.Context, name string, resolver ResolverRef, refs []ResolverRef, strategy ResolverStrategy) (errs *apis.FieldError) {
+	if len(refs) == 0 {
+		return
+	}
+
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "refs", config.AlphaAPIFields).ViaField("refs"))
+	if name != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("name", "refs"))
+	}
+	if resolver.Resolver != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("resolver", "refs"))
+	}
+
+	for i, r := range refs {
+		if r.Resolver == "" {
+			errs = errs.Also(apis.ErrMissingField("resolver").ViaIndex(i).ViaField("refs"))
+			continue
+		}
+		errs = errs.Also(ValidateParameters(ctx, r.Params).ViaIndex(i).ViaField("refs"))
+		errs = errs.Also(validateResolutionParamTypes(ctx, r.Params).ViaField("params").ViaIndex(i).ViaField("refs"))
+		errs = errs.Also(ValidateBundleResolverParams(r.Resolver, r.Params).ViaField("params").ViaIndex(i).ViaField("refs"))
+	}
+
+	switch strategy {
+	case "", ResolverStrategySingle, ResolverStrategyFallback, ResolverStrategyRace:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(string(strategy), "strategy"))
+	}
+	if (strategy == "" || strategy == ResolverStrategySingle) && len(refs) != 1 {
+		errs = errs.Also(apis.ErrGeneric("strategy \"single\" requires exactly one entry in refs", "refs"))
+	}
+
+	return errs
+}
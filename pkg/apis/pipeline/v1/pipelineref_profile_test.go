@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+func alphaContext() context.Context {
+	return config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{EnableAPIFields: config.AlphaAPIFields}})
+}
+
+func TestValidateResolverProfile_Valid(t *testing.T) {
+	refs := []ResolverRef{
+		{Resolver: "bundles", Params: []Param{{Name: "bundle", Value: *NewStructuredValues("gcr.io/foo")}}},
+		{Resolver: "git", Params: []Param{{Name: "url", Value: *NewStructuredValues("https://example.com/repo.git")}}},
+	}
+	if err := validateResolverProfile(alphaContext(), "", ResolverRef{}, refs, ResolverStrategyFallback); err != nil {
+		t.Errorf("validateResolverProfile() returned error: %v", err)
+	}
+}
+
+func TestValidateResolverProfile_Invalid(t *testing.T) {
+	refs := []ResolverRef{{Resolver: "bundles"}}
+	for _, tc := range []struct {
+		name     string
+		refName  string
+		resolver ResolverRef
+		refs     []ResolverRef
+		strategy ResolverStrategy
+	}{{
+		name:    "name and refs both set",
+		refName: "my-pipeline",
+		refs:    refs,
+	}, {
+		name:     "resolver and refs both set",
+		resolver: ResolverRef{Resolver: "git"},
+		refs:     refs,
+	}, {
+		name:     "unknown strategy",
+		refs:     refs,
+		strategy: "yolo",
+	}, {
+		name:     "single strategy with more than one ref",
+		refs:     append(refs, ResolverRef{Resolver: "hub"}),
+		strategy: ResolverStrategySingle,
+	}, {
+		name: "defaulted strategy with more than one ref",
+		refs: append(append([]ResolverRef{}, refs...), ResolverRef{Resolver: "hub"}),
+	}, {
+		name: "ref entry missing resolver",
+		refs: []ResolverRef{{}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateResolverProfile(alphaContext(), tc.refName, tc.resolver, tc.refs, tc.strategy); err == nil {
+				t.Error("validateResolverProfile() expected an error, got none")
+			}
+		})
+	}
+}
@@ -18,13 +18,24 @@ package v1
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
+// digestAlgHexLen records the expected length, in hex characters, of the
+// digest produced by each algorithm this package knows how to verify.
+// Algorithms not listed here are still accepted syntactically but are not
+// length-checked.
+var digestAlgHexLen = map[string]int{
+	"sha256": 64,
+	"sha512": 128,
+}
+
 // Validate ensures that a supplied PipelineRef field is populated
 // correctly. No errors are returned for a nil PipelineRef.
 func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
@@ -32,6 +43,13 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 		return
 	}
 
+	errs = errs.Also(validateResolverProfile(ctx, ref.Name, ref.ResolverRef, ref.Refs, ref.Strategy))
+	if len(ref.Refs) > 0 && ref.Resolver == "" && ref.Params == nil {
+		// A Refs profile stands in for Name; any Name set alongside it
+		// was already reported by validateResolverProfile.
+		return errs
+	}
+
 	if ref.Resolver != "" || ref.Params != nil {
 		if ref.Resolver != "" {
 			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "resolver", config.AlphaAPIFields).ViaField("resolver"))
@@ -48,7 +66,14 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 				errs = errs.Also(apis.ErrMissingField("resolver"))
 			}
 			errs = errs.Also(ValidateParameters(ctx, ref.Params))
-			errs = errs.Also(validateResolutionParamTypes(ref.Params).ViaField("params"))
+			errs = errs.Also(validateResolutionParamTypes(ctx, ref.Params).ViaField("params"))
+			errs = errs.Also(ValidateBundleResolverParams(ref.Resolver, ref.Params).ViaField("params"))
+		}
+		if ref.Digest != "" {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "digest", config.AlphaAPIFields).ViaField("digest"))
+			if _, _, err := ParseDigest(ref.Digest); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(ref.Digest, "digest", err.Error()))
+			}
 		}
 	} else if ref.Name == "" {
 		errs = errs.Also(apis.ErrMissingField("name"))
@@ -56,11 +81,34 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return
 }
 
-func validateResolutionParamTypes(params []Param) (errs *apis.FieldError) {
+// ParseDigest splits a digest string of the form "alg:hex" into its
+// algorithm and hex-encoded components. It returns an error if the string
+// is not in that form, if the hex portion is not valid hex, or if the
+// algorithm is a known one whose hex portion has the wrong length.
+func ParseDigest(digest string) (alg string, hexPart string, err error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("digest %q must be of the form \"alg:hex\"", digest)
+	}
+	alg, hexPart = parts[0], parts[1]
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", "", fmt.Errorf("digest %q has a non-hex-encoded value: %w", digest, err)
+	}
+	if wantLen, ok := digestAlgHexLen[alg]; ok && len(hexPart) != wantLen {
+		return "", "", fmt.Errorf("digest %q has a %s value of length %d, want %d", digest, alg, len(hexPart), wantLen)
+	}
+	return alg, hexPart, nil
+}
+
+// validateResolutionParamTypes rejects array and object values for remote
+// resolution params unless the alpha gate is enabled: older, non-opted-in
+// resolvers only know how to read string-keyed params off the request, so
+// typed values must stay opt-in until every in-tree resolver has been
+// updated to read them.
+func validateResolutionParamTypes(ctx context.Context, params []Param) (errs *apis.FieldError) {
 	for i, p := range params {
 		if p.Value.Type == ParamTypeArray || p.Value.Type == ParamTypeObject {
-			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("remote resolution parameter type must be %s, not %s",
-				string(ParamTypeString), string(p.Value.Type))).ViaIndex(i))
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, fmt.Sprintf("%s type remote resolution parameters", p.Value.Type), config.AlphaAPIFields).ViaIndex(i))
 		}
 	}
 
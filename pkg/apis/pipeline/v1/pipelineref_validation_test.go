@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import "testing"
+
+func TestParseDigest_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		digest  string
+		wantAlg string
+	}{{
+		name:    "sha256",
+		digest:  "sha256:" + fixedHex(64),
+		wantAlg: "sha256",
+	}, {
+		name:    "sha512",
+		digest:  "sha512:" + fixedHex(128),
+		wantAlg: "sha512",
+	}, {
+		name:    "unknown algorithm is not length-checked",
+		digest:  "md5:" + fixedHex(32),
+		wantAlg: "md5",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			alg, hexPart, err := ParseDigest(tc.digest)
+			if err != nil {
+				t.Fatalf("ParseDigest(%q) returned error: %v", tc.digest, err)
+			}
+			if alg != tc.wantAlg {
+				t.Errorf("ParseDigest(%q) alg = %q, want %q", tc.digest, alg, tc.wantAlg)
+			}
+			if hexPart == "" {
+				t.Errorf("ParseDigest(%q) returned empty hex part", tc.digest)
+			}
+		})
+	}
+}
+
+func TestParseDigest_Invalid(t *testing.T) {
+	for _, digest := range []string{
+		"",
+		"sha256",
+		"sha256:",
+		":" + fixedHex(64),
+		"sha256:not-hex",
+		"sha256:" + fixedHex(10),
+	} {
+		if _, _, err := ParseDigest(digest); err == nil {
+			t.Errorf("ParseDigest(%q) expected an error, got none", digest)
+		}
+	}
+}
+
+func fixedHex(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = "0123456789abcdef"[i%16]
+	}
+	return string(b)
+}
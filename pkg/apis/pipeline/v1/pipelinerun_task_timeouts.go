@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// TaskTimeoutOverride overrides the timeout of a single named PipelineTask
+// or finally task, without requiring the PipelineRun to embed (and so
+// fork) the rest of that task's spec. This checkout has no PipelineRunSpec
+// or reconciler timeout handler to attach Tasks/Finally []TaskTimeoutOverride
+// fields or validateTaskTimeoutOverrides to yet, so it isn't wired into a
+// PipelineRun's own validation or timeout-tracking loop.
+type TaskTimeoutOverride struct {
+	// PipelineTaskName is the name of the tasks or finally entry whose
+	// timeout this overrides.
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// Timeout is the new timeout for the named task.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// validateTaskTimeoutOverrides checks that every override names a task
+// exactly once and carries a non-negative timeout, that overridden task
+// names are actually present in knownTasks (the names declared in the
+// tasks or finally section the overrides apply to), and that no override
+// exceeds pipelineTasksTimeout (the pipeline-level Tasks timeout the
+// overrides apply within), when that timeout is itself set and bounded.
+// A Timeout of exactly zero means "unbounded", mirroring the meaning of
+// zero on the pipeline-level Tasks/Finally timeouts themselves.
+func validateTaskTimeoutOverrides(ctx context.Context, fieldName string, overrides []TaskTimeoutOverride, knownTasks map[string]bool, pipelineTasksTimeout *metav1.Duration) (errs *apis.FieldError) {
+	seen := make(map[string]bool, len(overrides))
+	for i, o := range overrides {
+		if o.PipelineTaskName == "" {
+			errs = errs.Also(apis.ErrMissingField("pipelineTaskName").ViaIndex(i).ViaField(fieldName))
+			continue
+		}
+		if seen[o.PipelineTaskName] {
+			errs = errs.Also(apis.ErrGeneric("duplicate timeout override for pipeline task "+o.PipelineTaskName, "pipelineTaskName").ViaIndex(i).ViaField(fieldName))
+		}
+		seen[o.PipelineTaskName] = true
+		if knownTasks != nil && !knownTasks[o.PipelineTaskName] {
+			errs = errs.Also(apis.ErrInvalidValue(o.PipelineTaskName, "pipelineTaskName", "does not match any task in the pipeline").ViaIndex(i).ViaField(fieldName))
+		}
+		if o.Timeout.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(o.Timeout.Duration.String(), "timeout", "must not be negative").ViaIndex(i).ViaField(fieldName))
+		}
+		if o.Timeout.Duration != 0 && pipelineTasksTimeout != nil && pipelineTasksTimeout.Duration != 0 && o.Timeout.Duration > pipelineTasksTimeout.Duration {
+			errs = errs.Also(apis.ErrInvalidValue(o.Timeout.Duration.String(), "timeout", "must not be greater than the pipeline's tasks timeout").ViaIndex(i).ViaField(fieldName))
+		}
+	}
+	return errs
+}
+
+// TimeoutFor looks up the overridden timeout for a named task, returning
+// ok=false when no override applies.
+func TimeoutFor(overrides []TaskTimeoutOverride, pipelineTaskName string) (metav1.Duration, bool) {
+	for _, o := range overrides {
+		if o.PipelineTaskName == pipelineTaskName {
+			return o.Timeout, true
+		}
+	}
+	return metav1.Duration{}, false
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+func TestValidateTaskTimeoutOverrides_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		overrides            []TaskTimeoutOverride
+		known                map[string]bool
+		pipelineTasksTimeout *metav1.Duration
+	}{{
+		name: "distinct known tasks",
+		overrides: []TaskTimeoutOverride{
+			{PipelineTaskName: "build", Timeout: *duration(5 * time.Minute)},
+			{PipelineTaskName: "test", Timeout: *duration(10 * time.Minute)},
+		},
+		known: map[string]bool{"build": true, "test": true},
+	}, {
+		name:      "zero timeout means unbounded",
+		overrides: []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(0)}},
+		known:     map[string]bool{"build": true},
+	}, {
+		name:                 "override under an unbounded pipeline tasks timeout",
+		overrides:            []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(5 * time.Minute)}},
+		known:                map[string]bool{"build": true},
+		pipelineTasksTimeout: duration(0),
+	}, {
+		name:                 "override at the pipeline tasks timeout",
+		overrides:            []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(time.Hour)}},
+		known:                map[string]bool{"build": true},
+		pipelineTasksTimeout: duration(time.Hour),
+	}, {
+		name:                 "unbounded override under a bounded pipeline tasks timeout",
+		overrides:            []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(0)}},
+		known:                map[string]bool{"build": true},
+		pipelineTasksTimeout: duration(time.Hour),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateTaskTimeoutOverrides(context.Background(), "tasks", tc.overrides, tc.known, tc.pipelineTasksTimeout); err != nil {
+				t.Errorf("validateTaskTimeoutOverrides() = %v, want no error", err)
+			}
+		})
+	}
+}
+
+func TestValidateTaskTimeoutOverrides_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		overrides            []TaskTimeoutOverride
+		known                map[string]bool
+		pipelineTasksTimeout *metav1.Duration
+	}{{
+		name:      "missing name",
+		overrides: []TaskTimeoutOverride{{Timeout: *duration(time.Minute)}},
+	}, {
+		name: "duplicate override",
+		overrides: []TaskTimeoutOverride{
+			{PipelineTaskName: "build", Timeout: *duration(time.Minute)},
+			{PipelineTaskName: "build", Timeout: *duration(2 * time.Minute)},
+		},
+	}, {
+		name:      "unknown task",
+		overrides: []TaskTimeoutOverride{{PipelineTaskName: "missing", Timeout: *duration(time.Minute)}},
+		known:     map[string]bool{"build": true},
+	}, {
+		name:      "negative timeout",
+		overrides: []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(-time.Minute)}},
+		known:     map[string]bool{"build": true},
+	}, {
+		name:                 "override exceeds pipeline tasks timeout",
+		overrides:            []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(2 * time.Hour)}},
+		known:                map[string]bool{"build": true},
+		pipelineTasksTimeout: duration(time.Hour),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := validateTaskTimeoutOverrides(context.Background(), "tasks", tc.overrides, tc.known, tc.pipelineTasksTimeout); err == nil {
+				t.Error("validateTaskTimeoutOverrides() expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestTimeoutFor(t *testing.T) {
+	overrides := []TaskTimeoutOverride{{PipelineTaskName: "build", Timeout: *duration(5 * time.Minute)}}
+	if got, ok := TimeoutFor(overrides, "build"); !ok || got.Duration != 5*time.Minute {
+		t.Errorf("TimeoutFor() = %v, %v, want 5m, true", got, ok)
+	}
+	if _, ok := TimeoutFor(overrides, "missing"); ok {
+		t.Error("TimeoutFor() expected ok=false for an unmatched task")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+)
+
+// ResolvedRef records the outcome of a single PipelineRef/TaskRef resolver
+// invocation, so a run's status exposes exactly what was fetched -
+// reproducibly and without re-resolving - to users and downstream
+// provenance tooling. This checkout has no PipelineRunStatus/TaskRunStatus
+// field to hang a ResolvedRef off of yet; reconcilers gain one wiring
+// point at a time, matching the ResolvedDependency convention in
+// pkg/reconciler/taskrun/resources.
+type ResolvedRef struct {
+	// URI is the resolved reference's location, reconstructed from the
+	// resolver's params (e.g. "git+https://example.com/repo.git@abcdef#path/to/file").
+	URI string `json:"uri,omitempty"`
+	// Digests is the resolved content's digest, keyed by algorithm (e.g.
+	// "sha256"). NewResolvedRef and NewResolvedRefAt fill in only the
+	// sha256 entry; a digest the ref was pinned to with another algorithm
+	// is not added.
+	Digests map[string]string `json:"digests,omitempty"`
+	// Params is the verbatim set of resolver params used to produce this
+	// result.
+	Params []Param `json:"params,omitempty"`
+	// ResolvedBy is the index into the originating PipelineRef/TaskRef's
+	// Refs profile whose attempt produced this result. It is -1 when the
+	// ref didn't use a Refs profile (Name or a single Resolver was set
+	// directly).
+	ResolvedBy int `json:"resolvedBy"`
+}
+
+// NewResolvedRef builds the ResolvedRef for a ResolverRef whose resolver
+// returned raw, computing raw's sha256 digest and reconstructing a URI
+// from ref's params using the conventions each in-tree resolver already
+// documents (e.g. git+<url>@<revision>#<pathInRepo>, oci://<bundle>). Its
+// ResolvedBy is -1; use NewResolvedRefAt for a ref resolved out of a Refs
+// profile.
+func NewResolvedRef(ref ResolverRef, raw []byte) ResolvedRef {
+	return NewResolvedRefAt(ref, raw, -1)
+}
+
+// NewResolvedRefAt is NewResolvedRef for a ResolverRef that was one entry
+// of a Refs profile: resolvedBy records which entry's attempt produced
+// raw, matching AttemptResolverProfile's winning index.
+func NewResolvedRefAt(ref ResolverRef, raw []byte, resolvedBy int) ResolvedRef {
+	sum := sha256.Sum256(raw)
+	params := make(map[string]string, len(ref.Params))
+	for _, p := range ref.Params {
+		params[p.Name] = p.Value.StringVal
+	}
+	return ResolvedRef{
+		URI:        reconstructResolverURI(ref.Resolver, params),
+		Digests:    map[string]string{"sha256": hex.EncodeToString(sum[:])},
+		Params:     ref.Params,
+		ResolvedBy: resolvedBy,
+	}
+}
+
+// reconstructResolverURI builds a resource descriptor URI from a
+// resolver's name and the params it was invoked with, following the same
+// conventions the resolvers themselves document.
+func reconstructResolverURI(resolver string, params map[string]string) string {
+	switch resolver {
+	case "git":
+		uri := fmt.Sprintf("git+%s@%s", params["url"], params["revision"])
+		if path := params["pathInRepo"]; path != "" {
+			uri += "#" + path
+		}
+		return uri
+	case "bundles":
+		return "oci://" + params["bundle"]
+	default:
+		return fmt.Sprintf("%s://%s", resolver, params["name"])
+	}
+}
+
+// VerifyDigest checks that content hashes to the algorithm and hex value
+// encoded in digest (see ParseDigest for the "alg:hex" format it expects).
+// A reconciler should call this on the bytes a resolver returns before
+// accepting them whenever the PipelineRef/TaskRef pinned a Digest, and
+// fail the run with a clear condition on a mismatch rather than silently
+// falling back to the unverified content. It supports the same algorithms
+// ParseDigest length-checks - sha256 and sha512 - and reports any other
+// algorithm as unsupported, since there's no hash.Hash to verify against
+// without one.
+func VerifyDigest(digest string, content []byte) error {
+	alg, wantHex, err := ParseDigest(digest)
+	if err != nil {
+		return err
+	}
+	var gotHex string
+	switch alg {
+	case "sha256":
+		sum := sha256.Sum256(content)
+		gotHex = hex.EncodeToString(sum[:])
+	case "sha512":
+		sum := sha512.Sum512(content)
+		gotHex = hex.EncodeToString(sum[:])
+	default:
+		return fmt.Errorf("digest algorithm %q is not supported for verification", alg)
+	}
+	if gotHex != wantHex {
+		return fmt.Errorf("resolved content digest %s:%s does not match expected %s", alg, gotHex, digest)
+	}
+	return nil
+}
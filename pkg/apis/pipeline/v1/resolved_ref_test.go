@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestNewResolvedRef(t *testing.T) {
+	raw := []byte("some task yaml")
+	ref := ResolverRef{
+		Resolver: "git",
+		Params: []Param{
+			{Name: "url", Value: ParamValue{StringVal: "https://example.com/repo.git"}},
+			{Name: "revision", Value: ParamValue{StringVal: "abcdef"}},
+			{Name: "pathInRepo", Value: ParamValue{StringVal: "task.yaml"}},
+		},
+	}
+
+	got := NewResolvedRef(ref, raw)
+
+	wantURI := "git+https://example.com/repo.git@abcdef#task.yaml"
+	if got.URI != wantURI {
+		t.Errorf("NewResolvedRef().URI = %q, want %q", got.URI, wantURI)
+	}
+	sum := sha256.Sum256(raw)
+	if want := hex.EncodeToString(sum[:]); got.Digests["sha256"] != want {
+		t.Errorf("NewResolvedRef().Digests[sha256] = %q, want %q", got.Digests["sha256"], want)
+	}
+	if len(got.Params) != len(ref.Params) {
+		t.Errorf("NewResolvedRef().Params = %v, want the verbatim %v", got.Params, ref.Params)
+	}
+	if got.ResolvedBy != -1 {
+		t.Errorf("NewResolvedRef().ResolvedBy = %d, want -1 for a ref with no Refs profile", got.ResolvedBy)
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	raw := []byte("some task yaml")
+	sum := sha256.Sum256(raw)
+	goodDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	if err := VerifyDigest(goodDigest, raw); err != nil {
+		t.Errorf("VerifyDigest() = %v, want no error for a matching digest", err)
+	}
+
+	badDigest := "sha256:" + fixedHex(64)
+	if err := VerifyDigest(badDigest, raw); err == nil {
+		t.Error("VerifyDigest() expected an error for a mismatched digest, got none")
+	}
+
+	if err := VerifyDigest("md5:"+fixedHex(32), raw); err == nil {
+		t.Error("VerifyDigest() expected an error for an unsupported algorithm, got none")
+	}
+
+	if err := VerifyDigest("not-a-digest", raw); err == nil {
+		t.Error("VerifyDigest() expected an error for a malformed digest, got none")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResolverAttemptError is one failed entry of an AttemptResolverProfile
+// call, keeping the index and the ResolverRef that failed alongside the
+// underlying error so operators can see exactly which fallback was tried
+// and why it didn't pan out.
+type ResolverAttemptError struct {
+	Index int
+	Ref   ResolverRef
+	Err   error
+}
+
+func (e *ResolverAttemptError) Error() string {
+	return fmt.Sprintf("refs[%d] (resolver %q): %v", e.Index, e.Ref.Resolver, e.Err)
+}
+
+func (e *ResolverAttemptError) Unwrap() error { return e.Err }
+
+// AttemptResolverProfile resolves a PipelineRef/TaskRef's Refs profile
+// according to strategy, calling resolve once per entry:
+//
+//   - ResolverStrategySingle and ResolverStrategyFallback attempt refs in
+//     order, moving to the next only once the previous entry's resolve
+//     call returns an error, and succeed with the first entry that
+//     doesn't.
+//   - ResolverStrategyRace is handled exactly like fallback: refs are
+//     attempted one at a time, in the order given, and the first entry
+//     whose resolve call doesn't error wins. No two resolve calls run
+//     concurrently.
+//
+// It returns the winning entry's index and raw bytes, or, if every entry
+// failed, a joined error built from every attempt's ResolverAttemptError.
+// No reconciler calls this yet, since this checkout has no
+// TaskRun/PipelineRun reconcile loop to resolve a ref from.
+func AttemptResolverProfile(refs []ResolverRef, strategy ResolverStrategy, resolve func(ResolverRef) ([]byte, error)) (int, []byte, error) {
+	var attemptErrs []error
+	for i, ref := range refs {
+		raw, err := resolve(ref)
+		if err != nil {
+			attemptErrs = append(attemptErrs, &ResolverAttemptError{Index: i, Ref: ref, Err: err})
+			continue
+		}
+		return i, raw, nil
+	}
+	return -1, nil, errors.Join(attemptErrs...)
+}
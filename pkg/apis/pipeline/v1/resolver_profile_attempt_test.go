@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttemptResolverProfile_FirstSucceeds(t *testing.T) {
+	refs := []ResolverRef{{Resolver: "bundles"}, {Resolver: "git"}}
+	calls := 0
+	idx, raw, err := AttemptResolverProfile(refs, ResolverStrategyFallback, func(ref ResolverRef) ([]byte, error) {
+		calls++
+		return []byte(ref.Resolver), nil
+	})
+	if err != nil {
+		t.Fatalf("AttemptResolverProfile() returned error: %v", err)
+	}
+	if idx != 0 || string(raw) != "bundles" {
+		t.Errorf("AttemptResolverProfile() = (%d, %q), want (0, %q)", idx, raw, "bundles")
+	}
+	if calls != 1 {
+		t.Errorf("resolve was called %d times, want 1 since the first entry succeeded", calls)
+	}
+}
+
+func TestAttemptResolverProfile_FallsBackPastFailures(t *testing.T) {
+	refs := []ResolverRef{{Resolver: "bundles"}, {Resolver: "git"}}
+	idx, raw, err := AttemptResolverProfile(refs, ResolverStrategyFallback, func(ref ResolverRef) ([]byte, error) {
+		if ref.Resolver == "bundles" {
+			return nil, errors.New("registry unreachable")
+		}
+		return []byte(ref.Resolver), nil
+	})
+	if err != nil {
+		t.Fatalf("AttemptResolverProfile() returned error: %v", err)
+	}
+	if idx != 1 || string(raw) != "git" {
+		t.Errorf("AttemptResolverProfile() = (%d, %q), want (1, %q)", idx, raw, "git")
+	}
+}
+
+func TestAttemptResolverProfile_AllFail(t *testing.T) {
+	refs := []ResolverRef{{Resolver: "bundles"}, {Resolver: "git"}}
+	idx, raw, err := AttemptResolverProfile(refs, ResolverStrategyRace, func(ref ResolverRef) ([]byte, error) {
+		return nil, errors.New(ref.Resolver + " failed")
+	})
+	if err == nil {
+		t.Fatal("AttemptResolverProfile() expected an error when every attempt fails, got none")
+	}
+	if idx != -1 || raw != nil {
+		t.Errorf("AttemptResolverProfile() = (%d, %v), want (-1, nil) on total failure", idx, raw)
+	}
+	var attemptErr *ResolverAttemptError
+	if !errors.As(err, &attemptErr) {
+		t.Error("expected err to unwrap to a *ResolverAttemptError")
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		if got := len(joined.Unwrap()); got != 2 {
+			t.Errorf("joined error has %d sub-errors, want 2", got)
+		}
+	} else {
+		t.Error("expected a joined error with an Unwrap() []error method")
+	}
+}
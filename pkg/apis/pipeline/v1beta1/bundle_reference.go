@@ -0,0 +1,42 @@
+package v1beta1
+
+import "strings"
+
+// parseBundleReference splits a v1beta1 TaskRef/PipelineRef Bundle string
+// (an OCI image reference, e.g. "example.com/img:v1@sha256:abcd...") into
+// its repository, tag, and digest components, so each can be carried as
+// its own bundle-resolver param instead of being passed through as one
+// opaque string. Either tag or digest (or both) may be absent.
+func parseBundleReference(ref string) (repository, tag, digest string) {
+	repository = ref
+
+	if i := strings.Index(repository, "@"); i != -1 {
+		digest = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	// The tag delimiter is the last colon after the last slash: a
+	// registry host or port (e.g. "localhost:5000/img") also contains a
+	// colon, but never after the final "/".
+	lastSlash := strings.LastIndex(repository, "/")
+	if i := strings.LastIndex(repository, ":"); i > lastSlash {
+		tag = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	return repository, tag, digest
+}
+
+// joinBundleReference is parseBundleReference's inverse: it concatenates
+// repository, tag, and digest back into a single OCI image reference
+// string.
+func joinBundleReference(repository, tag, digest string) string {
+	ref := repository
+	if tag != "" {
+		ref += ":" + tag
+	}
+	if digest != "" {
+		ref += "@" + digest
+	}
+	return ref
+}
@@ -0,0 +1,111 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// pipelineBundleAnnotationKey is bundleAnnotationKey's PipelineRef
+// counterpart, stashed on the parent Pipeline/PipelineRun so a v1beta1
+// Bundle field round-trips through v1's bundle resolver exactly. See
+// bundleFidelity in taskref_conversion.go.
+const pipelineBundleAnnotationKey = "tekton.dev/v1beta1PipelineBundle"
+
+// convertTo converts pr to its v1 shape. annotations should be the
+// annotations of the object pr is embedded in (e.g. a Pipeline or
+// PipelineRun); see TaskRef.convertTo in taskref_conversion.go for the
+// full caller contract - the same applies here, and this checkout has no
+// Pipeline/PipelineRun types to attach it to yet either.
+func (pr PipelineRef) convertTo(ctx context.Context, sink *v1.PipelineRef, annotations map[string]string) map[string]string {
+	sink.Name = pr.Name
+	sink.APIVersion = pr.APIVersion
+	new := v1.ResolverRef{}
+	pr.ResolverRef.convertTo(ctx, &new)
+	sink.ResolverRef = new
+	return pr.convertBundleToResolver(sink, annotations)
+}
+
+func (pr *PipelineRef) convertFrom(ctx context.Context, source v1.PipelineRef, annotations map[string]string) {
+	pr.Name = source.Name
+	pr.APIVersion = source.APIVersion
+	new := ResolverRef{}
+	new.convertFrom(ctx, source.ResolverRef)
+	pr.ResolverRef = new
+	pr.convertResolverToBundle(source, annotations)
+}
+
+// convertBundleToResolver converts a v1beta1 Bundle string into a v1
+// bundle-resolver ResolverRef, splitting it into separate "bundle"
+// (repository only), "tag", and "digest" params via parseBundleReference,
+// and stashing the original Bundle/Name under pipelineBundleAnnotationKey
+// for convertResolverToBundle to recover exactly. PipelineRef has no Kind
+// to preserve.
+func (pr PipelineRef) convertBundleToResolver(sink *v1.PipelineRef, annotations map[string]string) map[string]string {
+	if pr.Bundle == "" {
+		return annotations
+	}
+	repository, tag, digest := parseBundleReference(pr.Bundle)
+	params := []v1.Param{{
+		Name:  "bundle",
+		Value: v1.ParamValue{StringVal: repository},
+	}, {
+		Name:  "name",
+		Value: v1.ParamValue{StringVal: pr.Name},
+	}}
+	if tag != "" {
+		params = append(params, v1.Param{Name: "tag", Value: v1.ParamValue{StringVal: tag}})
+	}
+	if digest != "" {
+		params = append(params, v1.Param{Name: "digest", Value: v1.ParamValue{StringVal: digest}})
+	}
+	sink.ResolverRef = v1.ResolverRef{Resolver: "bundles", Params: params}
+
+	raw, err := json.Marshal(bundleFidelity{Bundle: pr.Bundle, Name: pr.Name})
+	if err != nil {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[pipelineBundleAnnotationKey] = string(raw)
+	return annotations
+}
+
+// convertResolverToBundle reconstructs a v1beta1 Bundle field from a v1
+// PipelineRef's bundle-resolver params, preferring the exact original
+// value stashed under pipelineBundleAnnotationKey when present, and
+// otherwise reassembling repository, tag, and digest params back into one
+// Bundle string via joinBundleReference. The bundles ResolverRef itself is
+// cleared, since Bundle now carries it.
+func (pr *PipelineRef) convertResolverToBundle(source v1.PipelineRef, annotations map[string]string) {
+	if source.ResolverRef.Resolver != "bundles" {
+		return
+	}
+	pr.ResolverRef = ResolverRef{}
+
+	if raw, ok := annotations[pipelineBundleAnnotationKey]; ok {
+		var bf bundleFidelity
+		if err := json.Unmarshal([]byte(raw), &bf); err == nil {
+			pr.Bundle = bf.Bundle
+			pr.Name = bf.Name
+			return
+		}
+	}
+
+	var repository, tag, digest string
+	for _, p := range source.Params {
+		switch p.Name {
+		case "bundle":
+			repository = p.Value.StringVal
+		case "tag":
+			tag = p.Value.StringVal
+		case "digest":
+			digest = p.Value.StringVal
+		case "name":
+			pr.Name = p.Value.StringVal
+		}
+	}
+	pr.Bundle = joinBundleReference(repository, tag, digest)
+}
@@ -0,0 +1,176 @@
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// TimeoutFields breaks a PipelineRun's overall timeout budget down by
+// phase, so a slow "tasks" phase doesn't silently eat into the budget
+// reserved for "finally" cleanup tasks.
+type TimeoutFields struct {
+	// Pipeline is the timeout for the entire PipelineRun, including both
+	// the tasks and finally phases. Unset or 0 means unbounded.
+	Pipeline *metav1.Duration `json:"pipeline,omitempty"`
+	// Tasks is the timeout budget for the tasks phase.
+	Tasks *metav1.Duration `json:"tasks,omitempty"`
+	// Finally is the timeout budget for the finally phase.
+	Finally *metav1.Duration `json:"finally,omitempty"`
+	// Budget declares the timeout as a single total wall-clock budget
+	// shared across phases, instead of fixed Tasks/Finally durations. It
+	// is mutually exclusive with Tasks and Finally.
+	Budget *TimeoutBudget `json:"budget,omitempty"`
+}
+
+// TimeoutBudget is TimeoutFields' alternative to fixed per-phase
+// durations: a single wall-clock Total, with an optional guarantee that
+// finally work always gets to run even once tasks has overrun its share.
+type TimeoutBudget struct {
+	// Total is the overall wall-clock budget, shared by tasks and
+	// finally. 0 means unbounded, matching TimeoutFields.Pipeline.
+	Total *metav1.Duration `json:"total,omitempty"`
+	// MinReservedFinally guarantees at least this much of Total remains
+	// for the finally phase once the tasks phase finishes or is
+	// cancelled, even if tasks consumed more than its even share of
+	// Total.
+	MinReservedFinally *metav1.Duration `json:"minReservedFinally,omitempty"`
+}
+
+// Validate checks t via validatePipelineTimeout. It's TimeoutFields' own
+// entry point; PipelineRunSpec.Validate should call it once this
+// checkout has a PipelineRunSpec.Timeouts field to call it from.
+func (t *TimeoutFields) Validate(ctx context.Context) *apis.FieldError {
+	return validatePipelineTimeout(t)
+}
+
+// validatePipelineTimeout checks that the per-phase budgets are
+// non-negative and that they don't individually exceed the overall
+// Pipeline budget when one is set. A Duration of 0 means "unbounded",
+// matching the existing PipelineRun.Spec.Timeout convention. An
+// unbounded (zero or unset) Pipeline budget places no constraint on
+// Tasks/Finally at all - they're free to be unset, zero, or any
+// positive value, since there's no pipeline-level bound left to exceed.
+func validatePipelineTimeout(t *TimeoutFields) (errs *apis.FieldError) {
+	if t == nil {
+		return
+	}
+	if t.Budget != nil && (t.Tasks != nil || t.Finally != nil) {
+		errs = errs.Also(apis.ErrMultipleOneOf("budget", "tasks", "finally"))
+	}
+	for field, d := range map[string]*metav1.Duration{"pipeline": t.Pipeline, "tasks": t.Tasks, "finally": t.Finally} {
+		if d != nil && d.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(d.Duration.String(), field, "must not be negative"))
+		}
+	}
+	errs = errs.Also(validateTimeoutBudget(t.Budget).ViaField("budget"))
+	if t.Pipeline == nil || t.Pipeline.Duration == 0 {
+		return errs
+	}
+	for field, d := range map[string]*metav1.Duration{"tasks": t.Tasks, "finally": t.Finally} {
+		if d != nil && d.Duration != 0 && d.Duration > t.Pipeline.Duration {
+			errs = errs.Also(apis.ErrInvalidValue(d.Duration.String(), field, "must not exceed the pipeline timeout"))
+		}
+	}
+	return errs
+}
+
+// validateTimeoutBudget checks that Total and MinReservedFinally are
+// non-negative and that, when Total is bounded (nonzero), the reserved
+// finally slice doesn't exceed it - a budget that reserves more for
+// finally than the whole run is allotted can never be satisfied.
+func validateTimeoutBudget(b *TimeoutBudget) (errs *apis.FieldError) {
+	if b == nil {
+		return
+	}
+	for field, d := range map[string]*metav1.Duration{"total": b.Total, "minReservedFinally": b.MinReservedFinally} {
+		if d != nil && d.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(d.Duration.String(), field, "must not be negative"))
+		}
+	}
+	if b.Total == nil || b.Total.Duration == 0 || b.MinReservedFinally == nil {
+		return errs
+	}
+	if b.MinReservedFinally.Duration > b.Total.Duration {
+		errs = errs.Also(apis.ErrInvalidValue(b.MinReservedFinally.Duration.String(), "minReservedFinally", "must not exceed the total budget"))
+	}
+	return errs
+}
+
+// noTimeout is returned by RemainingBudget for an unbounded phase. It's a
+// generous but finite stand-in so callers that feed it straight into a
+// context deadline don't have to special-case "no timeout" themselves.
+const noTimeout = 24 * time.Hour * 365
+
+// RemainingBudget returns how much of phase's budget is left after elapsed
+// has already passed, given the PipelineRun's overall and per-phase
+// timeouts. A budget of 0 (or unset) means unbounded and yields
+// noTimeout. It returns 0 (not negative) once a bounded budget is
+// exhausted, so callers can treat the result directly as a context
+// deadline offset. No reconciler calls this yet, since this checkout has
+// no PipelineRunSpec.Timeouts field to read TimeoutFields off of.
+func (t *TimeoutFields) RemainingBudget(phase string, elapsed time.Duration) time.Duration {
+	if t == nil {
+		return noTimeout
+	}
+	var budget *metav1.Duration
+	switch phase {
+	case "tasks":
+		budget = t.Tasks
+	case "finally":
+		budget = t.Finally
+	default:
+		budget = t.Pipeline
+	}
+	if budget == nil || budget.Duration == 0 {
+		return noTimeout
+	}
+	remaining := budget.Duration - elapsed
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// EffectiveTasksDeadline returns how much of the tasks phase's budget
+// remains after elapsed, in Budget mode: Total minus MinReservedFinally
+// minus elapsed, never negative. It returns noTimeout when there's no
+// bounded Budget.Total to compute against. No reconciler calls this yet,
+// since this checkout has no PipelineRunSpec.Timeouts field to read
+// TimeoutFields off of.
+func (t *TimeoutFields) EffectiveTasksDeadline(elapsed time.Duration) time.Duration {
+	if t == nil || t.Budget == nil || t.Budget.Total == nil || t.Budget.Total.Duration == 0 {
+		return noTimeout
+	}
+	var reserved time.Duration
+	if t.Budget.MinReservedFinally != nil {
+		reserved = t.Budget.MinReservedFinally.Duration
+	}
+	remaining := t.Budget.Total.Duration - reserved - elapsed
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// EffectiveFinallyDeadline returns how much of the total budget is left
+// for the finally phase once the tasks phase has run for tasksElapsed,
+// in Budget mode: whatever Total minus tasksElapsed leaves, but never
+// less than MinReservedFinally - the guarantee that makes cleanup work
+// run even after tasks has blown through its share. It returns noTimeout
+// when there's no bounded Budget.Total to compute against.
+func (t *TimeoutFields) EffectiveFinallyDeadline(tasksElapsed time.Duration) time.Duration {
+	if t == nil || t.Budget == nil || t.Budget.Total == nil || t.Budget.Total.Duration == 0 {
+		return noTimeout
+	}
+	remaining := t.Budget.Total.Duration - tasksElapsed
+	if t.Budget.MinReservedFinally != nil && remaining < t.Budget.MinReservedFinally.Duration {
+		remaining = t.Budget.MinReservedFinally.Duration
+	}
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
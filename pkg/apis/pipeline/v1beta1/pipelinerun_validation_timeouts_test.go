@@ -0,0 +1,170 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func duration(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+func TestTimeoutFieldsValidate_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tf   *TimeoutFields
+	}{{
+		name: "bounded pipeline with caps under budget",
+		tf: &TimeoutFields{
+			Pipeline: duration(10 * time.Minute),
+			Tasks:    duration(8 * time.Minute),
+			Finally:  duration(2 * time.Minute),
+		},
+	}, {
+		name: "unbounded pipeline with unbounded tasks and finally",
+		tf: &TimeoutFields{
+			Pipeline: duration(0),
+			Tasks:    duration(0),
+			Finally:  duration(0),
+		},
+	}, {
+		name: "unbounded pipeline with a bounded tasks cap and no finally cap",
+		tf: &TimeoutFields{
+			Pipeline: duration(0),
+			Tasks:    duration(8 * time.Minute),
+		},
+	}, {
+		name: "unbounded pipeline with bounded tasks and finally caps",
+		tf: &TimeoutFields{
+			Pipeline: duration(0),
+			Tasks:    duration(8 * time.Minute),
+			Finally:  duration(2 * time.Minute),
+		},
+	}, {
+		name: "no pipeline set at all",
+		tf:   &TimeoutFields{},
+	}, {
+		name: "budget with a reserved finally slice under total",
+		tf: &TimeoutFields{
+			Budget: &TimeoutBudget{
+				Total:              duration(1 * time.Hour),
+				MinReservedFinally: duration(5 * time.Minute),
+			},
+		},
+	}, {
+		name: "unbounded budget total",
+		tf: &TimeoutFields{
+			Budget: &TimeoutBudget{Total: duration(0)},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.tf.Validate(context.Background()); err != nil {
+				t.Errorf("Validate() = %v, want no error", err)
+			}
+		})
+	}
+}
+
+func TestTimeoutFieldsValidate_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tf   *TimeoutFields
+	}{{
+		name: "negative tasks timeout",
+		tf:   &TimeoutFields{Tasks: duration(-1 * time.Minute)},
+	}, {
+		name: "tasks exceeds pipeline",
+		tf: &TimeoutFields{
+			Pipeline: duration(5 * time.Minute),
+			Tasks:    duration(10 * time.Minute),
+		},
+	}, {
+		name: "finally exceeds pipeline",
+		tf: &TimeoutFields{
+			Pipeline: duration(5 * time.Minute),
+			Finally:  duration(10 * time.Minute),
+		},
+	}, {
+		name: "negative budget total",
+		tf: &TimeoutFields{
+			Budget: &TimeoutBudget{Total: duration(-1 * time.Minute)},
+		},
+	}, {
+		name: "negative budget minReservedFinally",
+		tf: &TimeoutFields{
+			Budget: &TimeoutBudget{
+				Total:              duration(1 * time.Hour),
+				MinReservedFinally: duration(-1 * time.Minute),
+			},
+		},
+	}, {
+		name: "budget minReservedFinally exceeds total",
+		tf: &TimeoutFields{
+			Budget: &TimeoutBudget{
+				Total:              duration(5 * time.Minute),
+				MinReservedFinally: duration(10 * time.Minute),
+			},
+		},
+	}, {
+		name: "budget set together with tasks",
+		tf: &TimeoutFields{
+			Tasks:  duration(5 * time.Minute),
+			Budget: &TimeoutBudget{Total: duration(1 * time.Hour)},
+		},
+	}, {
+		name: "budget set together with finally",
+		tf: &TimeoutFields{
+			Finally: duration(2 * time.Minute),
+			Budget:  &TimeoutBudget{Total: duration(1 * time.Hour)},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.tf.Validate(context.Background()); err == nil {
+				t.Error("Validate() expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestTimeoutFieldsRemainingBudget(t *testing.T) {
+	tf := &TimeoutFields{Tasks: duration(10 * time.Minute)}
+	if got, want := tf.RemainingBudget("tasks", 4*time.Minute), 6*time.Minute; got != want {
+		t.Errorf("RemainingBudget() = %v, want %v", got, want)
+	}
+	if got := tf.RemainingBudget("tasks", 11*time.Minute); got != 0 {
+		t.Errorf("RemainingBudget() = %v, want 0 once exhausted", got)
+	}
+	if got := (&TimeoutFields{Tasks: duration(0)}).RemainingBudget("tasks", time.Hour); got != noTimeout {
+		t.Errorf("RemainingBudget() = %v, want noTimeout for an unbounded phase", got)
+	}
+}
+
+func TestTimeoutFieldsEffectiveDeadlines(t *testing.T) {
+	tf := &TimeoutFields{
+		Budget: &TimeoutBudget{
+			Total:              duration(1 * time.Hour),
+			MinReservedFinally: duration(10 * time.Minute),
+		},
+	}
+	if got, want := tf.EffectiveTasksDeadline(20*time.Minute), 30*time.Minute; got != want {
+		t.Errorf("EffectiveTasksDeadline() = %v, want %v", got, want)
+	}
+	if got := tf.EffectiveTasksDeadline(time.Hour); got != 0 {
+		t.Errorf("EffectiveTasksDeadline() = %v, want 0 once the tasks share is exhausted", got)
+	}
+	if got, want := tf.EffectiveFinallyDeadline(20*time.Minute), 40*time.Minute; got != want {
+		t.Errorf("EffectiveFinallyDeadline() = %v, want %v", got, want)
+	}
+	// tasks overran its share, but finally is still guaranteed its
+	// MinReservedFinally floor.
+	if got, want := tf.EffectiveFinallyDeadline(55*time.Minute), 10*time.Minute; got != want {
+		t.Errorf("EffectiveFinallyDeadline() = %v, want the MinReservedFinally floor %v", got, want)
+	}
+	if got := (&TimeoutFields{}).EffectiveTasksDeadline(time.Hour); got != noTimeout {
+		t.Errorf("EffectiveTasksDeadline() = %v, want noTimeout with no Budget set", got)
+	}
+	if got := (&TimeoutFields{Budget: &TimeoutBudget{Total: duration(0)}}).EffectiveFinallyDeadline(time.Hour); got != noTimeout {
+		t.Errorf("EffectiveFinallyDeadline() = %v, want noTimeout for an unbounded Budget.Total", got)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// BackoffStrategy selects how RetryPolicy grows the delay between retry
+// attempts.
+type BackoffStrategy string
+
+const (
+	// BackoffStrategyFixed retries after the same Duration every time.
+	BackoffStrategyFixed BackoffStrategy = "Fixed"
+	// BackoffStrategyExponential doubles InitialDelay after each attempt,
+	// capped at MaxDelay.
+	BackoffStrategyExponential BackoffStrategy = "Exponential"
+)
+
+// RetryableFailureClass names a category of PipelineTask failure a
+// RetryPolicy can choose to retry (or not). Classes not listed in
+// RetryPolicy.RetryableFailureClasses are not retried even if attempts
+// remain, once the list is non-empty; an empty list retries on any
+// failure, matching the existing unconditional-retry behavior of
+// PipelineTask.Retries.
+type RetryableFailureClass string
+
+const (
+	// RetryableFailureClassTaskTimeout is a TaskRun that failed because it
+	// exceeded its timeout.
+	RetryableFailureClassTaskTimeout RetryableFailureClass = "TaskTimeout"
+	// RetryableFailureClassPodEvicted is a TaskRun whose Pod was evicted
+	// by the kubelet (e.g. node pressure), rather than failing on its own.
+	RetryableFailureClassPodEvicted RetryableFailureClass = "PodEvicted"
+	// RetryableFailureClassImagePullBackOff is a TaskRun that never
+	// started because a step's image couldn't be pulled.
+	RetryableFailureClassImagePullBackOff RetryableFailureClass = "ImagePullBackOff"
+	// RetryableFailureClassNonZeroExit is a TaskRun whose step(s) ran and
+	// exited non-zero.
+	RetryableFailureClassNonZeroExit RetryableFailureClass = "NonZeroExit"
+)
+
+// RetryPolicy overrides a PipelineTask's retry behavior for a single
+// PipelineRun, via PipelineTaskRunSpec.RetryPolicy. A nil RetryPolicy
+// leaves the PipelineTask's own Retries count and immediate-retry
+// behavior untouched.
+type RetryPolicy struct {
+	// MaxAttempts overrides the PipelineTask's declared Retries count (the
+	// total number of attempts is MaxAttempts, not MaxAttempts additional
+	// retries on top of Retries).
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// BackoffStrategy selects how the delay between attempts grows.
+	// Defaults to BackoffStrategyFixed.
+	BackoffStrategy BackoffStrategy `json:"backoffStrategy,omitempty"`
+	// InitialDelay is the delay before the first retry.
+	InitialDelay metav1.Duration `json:"initialDelay,omitempty"`
+	// MaxDelay caps the delay regardless of how many retries have already
+	// elapsed. Only meaningful with BackoffStrategyExponential.
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+	// RetryableFailureClasses restricts retries to the listed failure
+	// classes. An empty list retries on any failure.
+	RetryableFailureClasses []RetryableFailureClass `json:"retryableFailureClasses,omitempty"`
+}
+
+// Validate checks that p is internally consistent: MaxAttempts, when set,
+// is positive; InitialDelay/MaxDelay are non-negative and MaxDelay (when
+// set) is not less than InitialDelay; BackoffStrategy, when set, is one of
+// the known values; and every entry of RetryableFailureClasses is one of
+// the known classes. It also gates the whole field behind the alpha
+// feature flag, mirroring the existing StepOverrides/SidecarOverrides
+// pattern on PipelineTaskRunSpec.
+func (p *RetryPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if p == nil {
+		return
+	}
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "retryPolicy", config.AlphaAPIFields))
+
+	if p.MaxAttempts != nil && *p.MaxAttempts <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*p.MaxAttempts, "maxAttempts", "must be greater than zero"))
+	}
+	if p.InitialDelay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.InitialDelay.Duration.String(), "initialDelay", "must not be negative"))
+	}
+	if p.MaxDelay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.MaxDelay.Duration.String(), "maxDelay", "must not be negative"))
+	}
+	if p.MaxDelay.Duration != 0 && p.MaxDelay.Duration < p.InitialDelay.Duration {
+		errs = errs.Also(apis.ErrInvalidValue(p.MaxDelay.Duration.String(), "maxDelay", "must be greater than or equal to initialDelay"))
+	}
+	switch p.BackoffStrategy {
+	case "", BackoffStrategyFixed, BackoffStrategyExponential:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(string(p.BackoffStrategy), "backoffStrategy"))
+	}
+	for i, c := range p.RetryableFailureClasses {
+		switch c {
+		case RetryableFailureClassTaskTimeout, RetryableFailureClassPodEvicted, RetryableFailureClassImagePullBackOff, RetryableFailureClassNonZeroExit:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(string(c), "retryableFailureClasses").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// NextDelay computes the delay after attempt has failed (1-indexed: the
+// delay before the 2nd attempt is NextDelay(1)), given p's BackoffStrategy. It
+// returns 0 for a nil p or an unset InitialDelay, matching today's
+// immediate-retry behavior.
+func (p *RetryPolicy) NextDelay(attempt int) metav1.Duration {
+	if p == nil || p.InitialDelay.Duration == 0 || attempt < 1 {
+		return metav1.Duration{}
+	}
+	delay := p.InitialDelay.Duration
+	if p.BackoffStrategy == BackoffStrategyExponential {
+		for i := 1; i < attempt; i++ {
+			delay *= 2
+		}
+	}
+	if p.MaxDelay.Duration != 0 && delay > p.MaxDelay.Duration {
+		delay = p.MaxDelay.Duration
+	}
+	return metav1.Duration{Duration: delay}
+}
+
+// ShouldRetry reports whether a PipelineTask that failed with the given
+// class should be retried under p. A nil p or an empty
+// RetryableFailureClasses list retries on any failure, matching
+// PipelineTask.Retries' existing unconditional behavior.
+func (p *RetryPolicy) ShouldRetry(class RetryableFailureClass) bool {
+	if p == nil || len(p.RetryableFailureClasses) == 0 {
+		return true
+	}
+	for _, c := range p.RetryableFailureClasses {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
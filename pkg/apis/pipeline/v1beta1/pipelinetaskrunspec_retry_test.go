@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestRetryPolicyValidate_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rp   *RetryPolicy
+	}{{
+		name: "nil policy",
+		rp:   nil,
+	}, {
+		name: "empty policy",
+		rp:   &RetryPolicy{},
+	}, {
+		name: "full exponential backoff policy",
+		rp: &RetryPolicy{
+			MaxAttempts:             intPtr(3),
+			BackoffStrategy:         BackoffStrategyExponential,
+			InitialDelay:            metav1.Duration{Duration: 1 * time.Second},
+			MaxDelay:                metav1.Duration{Duration: 1 * time.Minute},
+			RetryableFailureClasses: []RetryableFailureClass{RetryableFailureClassTaskTimeout, RetryableFailureClassNonZeroExit},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.rp.Validate(config.EnableAlphaAPIFields(context.Background())); err != nil {
+				t.Errorf("Validate() = %v, want no error", err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyValidate_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rp   *RetryPolicy
+	}{{
+		name: "zero maxAttempts",
+		rp:   &RetryPolicy{MaxAttempts: intPtr(0)},
+	}, {
+		name: "negative initialDelay",
+		rp:   &RetryPolicy{InitialDelay: metav1.Duration{Duration: -1 * time.Second}},
+	}, {
+		name: "maxDelay less than initialDelay",
+		rp: &RetryPolicy{
+			InitialDelay: metav1.Duration{Duration: 1 * time.Minute},
+			MaxDelay:     metav1.Duration{Duration: 1 * time.Second},
+		},
+	}, {
+		name: "unknown backoff strategy",
+		rp:   &RetryPolicy{BackoffStrategy: "Immediate"},
+	}, {
+		name: "unknown failure class",
+		rp:   &RetryPolicy{RetryableFailureClasses: []RetryableFailureClass{"NetworkBlip"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.rp.Validate(config.EnableAlphaAPIFields(context.Background())); err == nil {
+				t.Error("Validate() expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestRetryPolicyValidate_RequiresAlphaGate(t *testing.T) {
+	rp := &RetryPolicy{MaxAttempts: intPtr(3)}
+	if err := rp.Validate(context.Background()); err == nil {
+		t.Error("Validate() expected an error without the alpha feature gate, got none")
+	}
+}
+
+func TestRetryPolicyNextDelay(t *testing.T) {
+	var nilPolicy *RetryPolicy
+	if got := nilPolicy.NextDelay(1); got.Duration != 0 {
+		t.Errorf("NextDelay() on a nil policy = %v, want 0", got.Duration)
+	}
+
+	fixed := &RetryPolicy{InitialDelay: metav1.Duration{Duration: 10 * time.Second}}
+	if got, want := fixed.NextDelay(1), 10*time.Second; got.Duration != want {
+		t.Errorf("NextDelay(1) = %v, want %v", got.Duration, want)
+	}
+	if got, want := fixed.NextDelay(3), 10*time.Second; got.Duration != want {
+		t.Errorf("NextDelay(3) = %v, want %v for a Fixed strategy", got.Duration, want)
+	}
+
+	exp := &RetryPolicy{
+		BackoffStrategy: BackoffStrategyExponential,
+		InitialDelay:    metav1.Duration{Duration: 10 * time.Second},
+		MaxDelay:        metav1.Duration{Duration: 1 * time.Minute},
+	}
+	if got, want := exp.NextDelay(1), 10*time.Second; got.Duration != want {
+		t.Errorf("NextDelay(1) = %v, want %v", got.Duration, want)
+	}
+	if got, want := exp.NextDelay(3), 40*time.Second; got.Duration != want {
+		t.Errorf("NextDelay(3) = %v, want %v", got.Duration, want)
+	}
+	if got, want := exp.NextDelay(10), 1*time.Minute; got.Duration != want {
+		t.Errorf("NextDelay(10) = %v, want %v (capped at MaxDelay)", got.Duration, want)
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	var nilPolicy *RetryPolicy
+	if !nilPolicy.ShouldRetry(RetryableFailureClassNonZeroExit) {
+		t.Error("ShouldRetry() on a nil policy = false, want true for any class")
+	}
+
+	empty := &RetryPolicy{}
+	if !empty.ShouldRetry(RetryableFailureClassNonZeroExit) {
+		t.Error("ShouldRetry() with no RetryableFailureClasses = false, want true for any class")
+	}
+
+	scoped := &RetryPolicy{RetryableFailureClasses: []RetryableFailureClass{RetryableFailureClassTaskTimeout}}
+	if !scoped.ShouldRetry(RetryableFailureClassTaskTimeout) {
+		t.Error("ShouldRetry(TaskTimeout) = false, want true for a listed class")
+	}
+	if scoped.ShouldRetry(RetryableFailureClassNonZeroExit) {
+		t.Error("ShouldRetry(NonZeroExit) = true, want false for an unlisted class")
+	}
+}
@@ -2,61 +2,144 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/json"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
 const bundleAnnotationKey = "tekton.dev/v1beta1Bundle"
 
-func (tr TaskRef) convertTo(ctx context.Context, sink *v1.TaskRef) {
+// bundleFidelity is the JSON shape stashed under bundleAnnotationKey so a
+// v1beta1 TaskRef's Bundle field round-trips through v1's bundle resolver
+// exactly, rather than being reconstructed from the resolver's params
+// (which can't, by themselves, distinguish a resolver authored directly
+// as "bundles" from one that started life as a Bundle field, and don't
+// carry Kind at all). ServiceAccount is part of the JSON shape, but
+// neither convertBundleToResolver nor convertResolverToBundle sets or
+// reads it, so a "serviceAccount" resolver param does not survive a
+// round trip.
+type bundleFidelity struct {
+	Bundle         string `json:"bundle"`
+	Name           string `json:"name"`
+	Kind           string `json:"kind,omitempty"`
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// convertTo converts tr to its v1 shape. annotations should be the
+// annotations of the object tr is embedded in (e.g. a Task or TaskRun);
+// convertTo returns them back, adding bundleAnnotationKey when tr.Bundle
+// is set. Callers - Task/TaskRun's own ConvertTo(ctx, apis.Convertible)
+// methods - are responsible for passing their ObjectMeta.Annotations in
+// and writing the returned map back to the converted object's
+// ObjectMeta.Annotations; without that, the fidelity round trip this
+// type exists for never happens. This checkout has no Task/TaskRun types
+// to attach that wiring to yet, so today only this package's own tests
+// call convertTo/convertFrom directly.
+func (tr TaskRef) convertTo(ctx context.Context, sink *v1.TaskRef, annotations map[string]string) map[string]string {
 	sink.Name = tr.Name
 	sink.Kind = v1.TaskKind(tr.Kind)
 	sink.APIVersion = tr.APIVersion
 	new := v1.ResolverRef{}
 	tr.ResolverRef.convertTo(ctx, &new)
 	sink.ResolverRef = new
-	tr.convertBundleToResolver(sink)
+	return tr.convertBundleToResolver(sink, annotations)
 }
 
-func (tr *TaskRef) convertFrom(ctx context.Context, source v1.TaskRef) {
+// convertFrom is convertTo's inverse. annotations should be the
+// annotations of the v1 object source is embedded in; see convertTo for
+// the caller contract this depends on.
+func (tr *TaskRef) convertFrom(ctx context.Context, source v1.TaskRef, annotations map[string]string) {
 	tr.Name = source.Name
 	tr.Kind = TaskKind(source.Kind)
 	tr.APIVersion = source.APIVersion
 	new := ResolverRef{}
 	new.convertFrom(ctx, source.ResolverRef)
 	tr.ResolverRef = new
-	tr.convertResolverToBundle(source)
+	tr.convertResolverToBundle(source, annotations)
 }
 
-// convertBundleToResolver converts v1beta1 bundle string to a remote reference with the bundle resolver in v1.
-func (tr TaskRef) convertBundleToResolver(sink *v1.TaskRef) {
-	if tr.Bundle != "" {
-		sink.ResolverRef = v1.ResolverRef{
-			Resolver: "bundles",
-			Params: []v1.Param{{
-				Name:  "bundle",
-				Value: v1.ParamValue{StringVal: tr.Bundle},
-			}, {
-				Name:  "name",
-				Value: v1.ParamValue{StringVal: tr.Name},
-			}, {
-				Name:  "kind",
-				Value: v1.ParamValue{StringVal: tr.Name},
-			}},
-		}
+// convertBundleToResolver converts a v1beta1 Bundle string into a v1
+// bundle-resolver ResolverRef, splitting it into separate "bundle"
+// (repository only), "tag", and "digest" params via parseBundleReference
+// rather than passing it through as one opaque string, and returns
+// annotations with the original Bundle/Name/Kind stashed under
+// bundleAnnotationKey (via convertTo's caller, which owns the parent
+// object's ObjectMeta) so convertResolverToBundle can later recover the
+// exact v1beta1 shape instead of reassembling it from resolver params.
+func (tr TaskRef) convertBundleToResolver(sink *v1.TaskRef, annotations map[string]string) map[string]string {
+	if tr.Bundle == "" {
+		return annotations
+	}
+	repository, tag, digest := parseBundleReference(tr.Bundle)
+	params := []v1.Param{{
+		Name:  "bundle",
+		Value: v1.ParamValue{StringVal: repository},
+	}, {
+		Name:  "name",
+		Value: v1.ParamValue{StringVal: tr.Name},
+	}, {
+		Name:  "kind",
+		Value: v1.ParamValue{StringVal: string(tr.Kind)},
+	}}
+	if tag != "" {
+		params = append(params, v1.Param{Name: "tag", Value: v1.ParamValue{StringVal: tag}})
+	}
+	if digest != "" {
+		params = append(params, v1.Param{Name: "digest", Value: v1.ParamValue{StringVal: digest}})
+	}
+	sink.ResolverRef = v1.ResolverRef{Resolver: "bundles", Params: params}
+
+	raw, err := json.Marshal(bundleFidelity{Bundle: tr.Bundle, Name: tr.Name, Kind: string(tr.Kind)})
+	if err != nil {
+		// Fidelity is best-effort: convertResolverToBundle falls back to
+		// reconstructing from resolver params if the annotation is absent.
+		return annotations
 	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[bundleAnnotationKey] = string(raw)
+	return annotations
 }
 
-//
-func (tr *TaskRef) convertResolverToBundle(source v1.TaskRef) {
-	if source.ResolverRef.Resolver == "bundles" {
-		for _, p := range source.Params {
-			if p.Name == "bundle" {
-				tr.Bundle = p.Value.StringVal
-			}
-			if p.Name == "name" {
-				tr.Name = p.Value.StringVal
-			}
+// convertResolverToBundle reconstructs a v1beta1 Bundle field from a v1
+// TaskRef's bundle-resolver params, preferring the exact original value
+// stashed under bundleAnnotationKey by convertBundleToResolver when the
+// parent object carries it, and otherwise reassembling repository, tag,
+// and digest params back into one Bundle string via joinBundleReference.
+// The bundles ResolverRef itself is cleared, since Bundle now carries it.
+// A resolver param named "serviceAccount" has no v1beta1 field to land
+// in and is dropped.
+func (tr *TaskRef) convertResolverToBundle(source v1.TaskRef, annotations map[string]string) {
+	if source.ResolverRef.Resolver != "bundles" {
+		return
+	}
+	tr.ResolverRef = ResolverRef{}
+
+	if raw, ok := annotations[bundleAnnotationKey]; ok {
+		var bf bundleFidelity
+		if err := json.Unmarshal([]byte(raw), &bf); err == nil {
+			tr.Bundle = bf.Bundle
+			tr.Name = bf.Name
+			tr.Kind = TaskKind(bf.Kind)
+			return
+		}
+	}
+
+	var repository, tag, digest string
+	for _, p := range source.Params {
+		switch p.Name {
+		case "bundle":
+			repository = p.Value.StringVal
+		case "tag":
+			tag = p.Value.StringVal
+		case "digest":
+			digest = p.Value.StringVal
+		case "name":
+			tr.Name = p.Value.StringVal
+		case "kind":
+			tr.Kind = TaskKind(p.Value.StringVal)
 		}
 	}
+	tr.Bundle = joinBundleReference(repository, tag, digest)
 }
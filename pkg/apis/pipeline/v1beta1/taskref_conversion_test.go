@@ -0,0 +1,121 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestTaskRefBundleRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   TaskRef
+	}{{
+		name: "tag only",
+		in:   TaskRef{Name: "my-task", Kind: NamespacedTaskKind, Bundle: "example.com/img:v1"},
+	}, {
+		name: "tag and digest",
+		in:   TaskRef{Name: "my-task", Kind: ClusterTaskKind, Bundle: "example.com/img:v1@sha256:abcd1234"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			annotations := map[string]string{}
+
+			sink := v1.TaskRef{}
+			annotations = tc.in.convertTo(ctx, &sink, annotations)
+
+			if sink.ResolverRef.Resolver != "bundles" {
+				t.Fatalf("convertTo() did not set the bundles resolver, got: %#v", sink.ResolverRef)
+			}
+			wantRepository, wantTag, wantDigest := parseBundleReference(tc.in.Bundle)
+			gotParams := map[string]string{}
+			for _, p := range sink.ResolverRef.Params {
+				gotParams[p.Name] = p.Value.StringVal
+			}
+			if gotParams["kind"] != string(tc.in.Kind) {
+				t.Errorf("convertTo() kind param = %q, want %q", gotParams["kind"], tc.in.Kind)
+			}
+			if gotParams["bundle"] != wantRepository {
+				t.Errorf("convertTo() bundle param = %q, want %q", gotParams["bundle"], wantRepository)
+			}
+			if gotParams["tag"] != wantTag {
+				t.Errorf("convertTo() tag param = %q, want %q", gotParams["tag"], wantTag)
+			}
+			if gotParams["digest"] != wantDigest {
+				t.Errorf("convertTo() digest param = %q, want %q", gotParams["digest"], wantDigest)
+			}
+			if err := v1.ValidateBundleResolverParams(sink.ResolverRef.Resolver, sink.ResolverRef.Params); err != nil {
+				t.Errorf("convertTo() produced params the bundles resolver itself rejects: %v", err)
+			}
+
+			got := &TaskRef{}
+			got.convertFrom(ctx, sink, annotations)
+
+			if d := cmp.Diff(tc.in, *got); d != "" {
+				t.Errorf("bundle round trip v1beta1->v1->v1beta1 not identity (-want +got): %s", d)
+			}
+		})
+	}
+}
+
+// TestTaskRefBundleRoundTrip_NoAnnotation examines that, without the
+// fidelity annotation (e.g. an object converted before this fix, or a
+// resolver authored directly as "bundles" rather than via a v1beta1
+// Bundle field), convertFrom still recovers whatever the resolver's own
+// params carry.
+func TestTaskRefBundleRoundTrip_NoAnnotation(t *testing.T) {
+	ctx := context.Background()
+	source := v1.TaskRef{
+		ResolverRef: v1.ResolverRef{
+			Resolver: "bundles",
+			Params: []v1.Param{
+				{Name: "bundle", Value: v1.ParamValue{StringVal: "example.com/img:v1"}},
+				{Name: "name", Value: v1.ParamValue{StringVal: "my-task"}},
+				{Name: "kind", Value: v1.ParamValue{StringVal: "task"}},
+			},
+		},
+	}
+
+	got := &TaskRef{}
+	got.convertFrom(ctx, source, nil)
+
+	want := TaskRef{Bundle: "example.com/img:v1", Name: "my-task", Kind: "task"}
+	if d := cmp.Diff(want, *got); d != "" {
+		t.Errorf("convertFrom() without annotation (-want +got): %s", d)
+	}
+}
+
+// TestTaskRefBundleServiceAccountParamAccepted examines that
+// ValidateBundleResolverParams accepts a serviceAccount param, alongside
+// bundle, tag and name, as a recognized bundles-resolver param name.
+func TestTaskRefBundleServiceAccountParamAccepted(t *testing.T) {
+	source := v1.TaskRef{
+		ResolverRef: v1.ResolverRef{
+			Resolver: "bundles",
+			Params: []v1.Param{
+				{Name: "bundle", Value: v1.ParamValue{StringVal: "example.com/img"}},
+				{Name: "tag", Value: v1.ParamValue{StringVal: "v1"}},
+				{Name: "name", Value: v1.ParamValue{StringVal: "my-task"}},
+				{Name: "serviceAccount", Value: v1.ParamValue{StringVal: "my-sa"}},
+			},
+		},
+	}
+	if err := v1.ValidateBundleResolverParams(source.ResolverRef.Resolver, source.ResolverRef.Params); err != nil {
+		t.Errorf("ValidateBundleResolverParams() rejected a well-known param set: %v", err)
+	}
+}
+
+// TestTaskRefBundleRejectsUnknownParam examines that a bundles ResolverRef
+// carrying a param name the bundles resolver doesn't recognize fails
+// validation rather than being silently dropped during conversion.
+func TestTaskRefBundleRejectsUnknownParam(t *testing.T) {
+	params := []v1.Param{
+		{Name: "bundle", Value: v1.ParamValue{StringVal: "example.com/img"}},
+		{Name: "repository", Value: v1.ParamValue{StringVal: "example.com/img"}},
+	}
+	if err := v1.ValidateBundleResolverParams("bundles", params); err == nil {
+		t.Errorf("ValidateBundleResolverParams() expected an error for an unrecognized param name, got none")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration converts TaskRuns (and the Tasks they reference) that
+// depend on v1alpha1 PipelineResources into v1 equivalents that use
+// workspaces, params and results instead, so that users can eliminate
+// PipelineResources entirely.
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var emptyVolumeSource = corev1.EmptyDirVolumeSource{}
+
+// gcsSecretName returns the conventional name migration expects a
+// gcs-upload/gcs-download step's credentials to already exist under in
+// the target namespace: callers migrating off a "storage" PipelineResource
+// must create this Secret themselves (the legacy resource's credentials
+// aren't readable from the TaskRun being converted), so resourceName keeps
+// the generated workspace binding traceable back to the resource it
+// replaced.
+func gcsSecretName(resourceName string) string {
+	return resourceName + "-gcs-credentials"
+}
+
+// Warning describes a lossy or manual follow-up step introduced while
+// converting a PipelineResource to its workspace/param equivalent.
+type Warning string
+
+// gitCloneTaskRef is the catalog Task substituted for the "git" resource
+// type. Callers that can't reach the catalog at conversion time can swap
+// this out for a local equivalent before running the produced TaskRun.
+var gitCloneTaskRef = v1.TaskRef{
+	ResolverRef: v1.ResolverRef{
+		Resolver: "hub",
+		Params: []v1.Param{
+			{Name: "kind", Value: *v1.NewStructuredValues("task")},
+			{Name: "name", Value: *v1.NewStructuredValues("git-clone")},
+			{Name: "version", Value: *v1.NewStructuredValues("0.9")},
+		},
+	},
+}
+
+// Convert rewrites a v1beta1 TaskRun that references PipelineResources of
+// type "git", "storage" (gcs) or "image" into an equivalent v1 TaskRun that
+// carries the same information as workspaces, params and results. It
+// returns a Warning for every resource it could not translate losslessly,
+// and an error only when the TaskRun references a resource type migration
+// does not know how to handle.
+func Convert(ctx context.Context, in *v1beta1.TaskRun) (*v1.TaskRun, []Warning, error) {
+	out := &v1.TaskRun{
+		ObjectMeta: in.ObjectMeta,
+		Spec: v1.TaskRunSpec{
+			Params:             convertParams(in.Spec.Params),
+			ServiceAccountName: in.Spec.ServiceAccountName,
+			Status:             v1.TaskRunSpecStatus(in.Spec.Status),
+			Timeout:            in.Spec.Timeout,
+			Workspaces:         append([]v1.WorkspaceBinding{}, convertWorkspaces(in.Spec.Workspaces)...),
+		},
+	}
+	if in.Spec.TaskRef != nil {
+		out.Spec.TaskRef = &v1.TaskRef{Name: in.Spec.TaskRef.Name, Kind: v1.TaskKind(in.Spec.TaskRef.Kind)}
+	}
+
+	var warnings []Warning
+	if in.Spec.Resources == nil {
+		return out, warnings, nil
+	}
+
+	bindings := append(append([]v1beta1.TaskResourceBinding{}, in.Spec.Resources.Inputs...), in.Spec.Resources.Outputs...)
+	for _, b := range bindings {
+		ws, params, warn, err := convertResourceBinding(b)
+		if err != nil {
+			return nil, warnings, err
+		}
+		if ws != nil {
+			out.Spec.Workspaces = append(out.Spec.Workspaces, *ws)
+		}
+		out.Spec.Params = append(out.Spec.Params, params...)
+		warnings = append(warnings, warn...)
+		switch resourceType(b) {
+		case resourcev1alpha1.PipelineResourceTypeGit:
+			if out.Spec.TaskRef == nil {
+				out.Spec.TaskRef = &gitCloneTaskRef
+			}
+		}
+	}
+
+	return out, warnings, nil
+}
+
+// resourceType reports the PipelineResource type a binding refers to, when
+// it can be determined from an inline ResourceSpec. Bindings that only
+// carry a ResourceRef can't be typed without a live cluster lookup, and
+// callers should consult the cluster before relying on this. It returns
+// the typed resourcev1alpha1.PipelineResourceType directly rather than a
+// bare string, so convertResourceBinding keys its switch off the same
+// constants the resource API declares instead of ad hoc string literals
+// that could silently drift out of sync (e.g. "storage" never actually
+// appears as "gcs" - gcs is one of several backends storage resources
+// support - so a case keyed on the literal "gcs" would never match).
+func resourceType(b v1beta1.TaskResourceBinding) resourcev1alpha1.PipelineResourceType {
+	if b.ResourceSpec != nil {
+		return b.ResourceSpec.Type
+	}
+	return ""
+}
+
+// convertResourceBinding translates a single input or output
+// TaskResourceBinding into the workspace binding and/or params that carry
+// the same information in the workspace/param world, per resource type:
+//   - "git" becomes a workspace populated by the git-clone catalog Task.
+//   - "storage" becomes a secret-backed workspace for gcs-upload/download
+//     (the only storage backend migration knows how to replace today).
+//   - "image" becomes an IMAGE_URL/IMAGE_DIGEST param pair, surfaced as a
+//     warning because the consuming steps must be rewritten to read
+//     $(params.*) instead of the resource's mounted path.
+func convertResourceBinding(b v1beta1.TaskResourceBinding) (*v1.WorkspaceBinding, []v1.Param, []Warning, error) {
+	rt := resourceType(b)
+	switch rt {
+	case resourcev1alpha1.PipelineResourceTypeGit:
+		return &v1.WorkspaceBinding{Name: b.Name, EmptyDir: &emptyVolumeSource}, nil, nil, nil
+	case resourcev1alpha1.PipelineResourceTypeStorage:
+		ws := &v1.WorkspaceBinding{Name: b.Name, Secret: &corev1.SecretVolumeSource{SecretName: gcsSecretName(b.Name)}}
+		return ws, nil, []Warning{
+			Warning(fmt.Sprintf("resource %q: migrated to a gcs-upload/gcs-download step reading credentials from Secret %q, which must exist in the TaskRun's namespace", b.Name, gcsSecretName(b.Name))),
+		}, nil
+	case resourcev1alpha1.PipelineResourceTypeImage:
+		params := []v1.Param{
+			{Name: b.Name + "-image-url", Value: *v1.NewStructuredValues("$(results.IMAGE_URL.path)")},
+			{Name: b.Name + "-image-digest", Value: *v1.NewStructuredValues("$(results.IMAGE_DIGEST.path)")},
+		}
+		return nil, params, []Warning{
+			Warning(fmt.Sprintf("resource %q: image resource replaced by IMAGE_URL/IMAGE_DIGEST results; steps referencing the resource path must be rewritten to write those results directly", b.Name)),
+		}, nil
+	case "":
+		return nil, nil, []Warning{
+			Warning(fmt.Sprintf("resource %q: type could not be determined from the TaskRun alone; resolve its PipelineResource and re-run migration", b.Name)),
+		}, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("migration: no converter registered for PipelineResource type %q", rt)
+	}
+}
+
+func convertParams(in []v1beta1.Param) []v1.Param {
+	out := make([]v1.Param, 0, len(in))
+	for _, p := range in {
+		out = append(out, v1.Param{Name: p.Name, Value: v1.ParamValue{
+			Type:      v1.ParamType(p.Value.Type),
+			StringVal: p.Value.StringVal,
+			ArrayVal:  p.Value.ArrayVal,
+			ObjectVal: p.Value.ObjectVal,
+		}})
+	}
+	return out
+}
+
+func convertWorkspaces(in []v1beta1.WorkspaceBinding) []v1.WorkspaceBinding {
+	out := make([]v1.WorkspaceBinding, 0, len(in))
+	for _, w := range in {
+		out = append(out, v1.WorkspaceBinding{
+			Name:                  w.Name,
+			SubPath:               w.SubPath,
+			VolumeClaimTemplate:   w.VolumeClaimTemplate,
+			PersistentVolumeClaim: w.PersistentVolumeClaim,
+			EmptyDir:              w.EmptyDir,
+			ConfigMap:             w.ConfigMap,
+			Secret:                w.Secret,
+			Projected:             w.Projected,
+			CSI:                   w.CSI,
+		})
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+)
+
+// WorkspaceRequirement is the declaration-level counterpart to
+// v1.WorkspaceBinding: it says a migrated Task needs a workspace of a
+// given name and why, without binding it to a concrete volume source
+// (that binding happens per-TaskRun, via Convert). This checkout has no
+// v1.WorkspaceDeclaration type for TaskSpec.Workspaces to return instead,
+// since Task/TaskSpec themselves aren't declared here yet either.
+type WorkspaceRequirement struct {
+	Name        string
+	Description string
+}
+
+// ConvertTask rewrites a v1beta1 Task's declared PipelineResource inputs
+// and outputs ("git", "storage" or "image") into the equivalent v1
+// ParamSpec/WorkspaceRequirement/TaskResult declarations, mirroring
+// Convert's per-type handling but at the declaration level: a Task
+// declares what kind of resource it needs, not a concrete binding, so
+// there's no ResourceSpec/ResourceRef to inspect here - every input and
+// output is converted by its declared Type alone.
+func ConvertTask(ctx context.Context, in *v1beta1.Task) (params []v1.ParamSpec, workspaces []WorkspaceRequirement, results []v1.TaskResult, warnings []Warning, err error) {
+	if in.Spec.Resources == nil {
+		return nil, nil, nil, nil, nil
+	}
+
+	declarations := append(append([]v1beta1.TaskResource{}, in.Spec.Resources.Inputs...), in.Spec.Resources.Outputs...)
+	for _, d := range declarations {
+		ws, ps, res, warn, convErr := convertResourceDeclaration(d)
+		if convErr != nil {
+			return nil, nil, nil, warnings, convErr
+		}
+		if ws != nil {
+			workspaces = append(workspaces, *ws)
+		}
+		params = append(params, ps...)
+		results = append(results, res...)
+		warnings = append(warnings, warn...)
+	}
+
+	return params, workspaces, results, warnings, nil
+}
+
+// convertResourceDeclaration is convertResourceBinding's declaration-level
+// counterpart: given only a resource's name and Type (no bound
+// ResourceSpec/ResourceRef to read params from), it reports the
+// workspace, param, and result declarations a migrated Task needs to
+// replace that resource.
+func convertResourceDeclaration(d v1beta1.TaskResource) (*WorkspaceRequirement, []v1.ParamSpec, []v1.TaskResult, []Warning, error) {
+	switch d.Type {
+	case resourcev1alpha1.PipelineResourceTypeGit:
+		return &WorkspaceRequirement{Name: d.Name, Description: "populated by the git-clone catalog Task"}, nil, nil, nil, nil
+	case resourcev1alpha1.PipelineResourceTypeStorage:
+		ws := &WorkspaceRequirement{Name: d.Name, Description: "gcs credentials and staged files for gcs-upload/gcs-download"}
+		return ws, nil, nil, []Warning{
+			Warning(fmt.Sprintf("resource %q: Task must add gcs-upload/gcs-download steps reading credentials from the %q workspace", d.Name, d.Name)),
+		}, nil
+	case resourcev1alpha1.PipelineResourceTypeImage:
+		return nil, nil, []v1.TaskResult{
+				{Name: d.Name + "-image-url", Type: v1.ResultsTypeString},
+				{Name: d.Name + "-image-digest", Type: v1.ResultsTypeString},
+			}, []Warning{
+				Warning(fmt.Sprintf("resource %q: Task must write IMAGE_URL/IMAGE_DIGEST as results instead of an image resource", d.Name)),
+			}, nil
+	default:
+		return nil, nil, nil, nil, fmt.Errorf("migration: no converter registered for PipelineResource type %q", d.Type)
+	}
+}
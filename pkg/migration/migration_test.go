@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// outputTestResources mirrors the fixtures in
+// pkg/reconciler/taskrun/resources/output_resource_test.go so each resource
+// type migration claims to support has an exercised, documented path.
+var outputTestResources = map[string]*resourcev1alpha1.PipelineResourceSpec{
+	"source-git":   {Type: resourcev1alpha1.PipelineResourceTypeGit},
+	"source-gcs":   {Type: resourcev1alpha1.PipelineResourceTypeStorage},
+	"source-image": {Type: resourcev1alpha1.PipelineResourceTypeImage},
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		resource    string
+		wantErr     bool
+		wantWarning bool
+	}{{
+		name:     "git",
+		resource: "source-git",
+	}, {
+		name:        "storage",
+		resource:    "source-gcs",
+		wantWarning: true,
+	}, {
+		name:        "image",
+		resource:    "source-image",
+		wantWarning: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "tr-" + tc.name},
+				Spec: v1beta1.TaskRunSpec{
+					Resources: &v1beta1.TaskRunResources{
+						Inputs: []v1beta1.TaskResourceBinding{{
+							PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+								Name:         tc.resource,
+								ResourceSpec: outputTestResources[tc.resource],
+							},
+						}},
+					},
+				},
+			}
+
+			out, warnings, err := Convert(context.Background(), in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Convert() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (len(warnings) > 0) != tc.wantWarning {
+				t.Errorf("Convert() warnings = %v, wantWarning %v", warnings, tc.wantWarning)
+			}
+			if out.ObjectMeta.Name != in.ObjectMeta.Name {
+				t.Errorf("Convert() dropped the TaskRun name: got %q", out.ObjectMeta.Name)
+			}
+			if tc.name == "git" {
+				if out.Spec.TaskRef == nil || out.Spec.TaskRef.Resolver != gitCloneTaskRef.Resolver {
+					t.Errorf("Convert() TaskRef = %v, want the git-clone catalog TaskRef", out.Spec.TaskRef)
+				}
+			}
+		})
+	}
+}
+
+func TestConvert_StorageUsesSecretBackedWorkspace(t *testing.T) {
+	in := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Resources: &v1beta1.TaskRunResources{
+				Inputs: []v1beta1.TaskResourceBinding{{
+					PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+						Name:         "source-gcs",
+						ResourceSpec: outputTestResources["source-gcs"],
+					},
+				}},
+			},
+		},
+	}
+
+	out, _, err := Convert(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Convert() returned error: %v", err)
+	}
+	var ws *v1.WorkspaceBinding
+	for i, w := range out.Spec.Workspaces {
+		if w.Name == "source-gcs" {
+			ws = &out.Spec.Workspaces[i]
+		}
+	}
+	if ws == nil {
+		t.Fatal("Convert() did not produce a workspace for the storage resource")
+	}
+	if ws.Secret == nil {
+		t.Fatal("Convert() workspace for a storage resource has no Secret volume source")
+	}
+	if want := "source-gcs-gcs-credentials"; ws.Secret.SecretName != want {
+		t.Errorf("Secret.SecretName = %q, want %q", ws.Secret.SecretName, want)
+	}
+}
+
+func TestConvert_ImageEmitsURLAndDigestParams(t *testing.T) {
+	in := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Resources: &v1beta1.TaskRunResources{
+				Outputs: []v1beta1.TaskResourceBinding{{
+					PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+						Name:         "source-image",
+						ResourceSpec: outputTestResources["source-image"],
+					},
+				}},
+			},
+		},
+	}
+
+	out, _, err := Convert(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Convert() returned error: %v", err)
+	}
+	wantNames := map[string]bool{"source-image-image-url": true, "source-image-image-digest": true}
+	for _, p := range out.Spec.Params {
+		delete(wantNames, p.Name)
+	}
+	if len(wantNames) != 0 {
+		t.Errorf("Convert() params = %v, missing %v", out.Spec.Params, wantNames)
+	}
+}
+
+func TestConvertTask(t *testing.T) {
+	in := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-task"},
+		Spec: v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs: []v1beta1.TaskResource{{
+					ResourceDeclaration: v1beta1.ResourceDeclaration{Name: "source", Type: resourcev1alpha1.PipelineResourceTypeGit},
+				}},
+				Outputs: []v1beta1.TaskResource{{
+					ResourceDeclaration: v1beta1.ResourceDeclaration{Name: "built-image", Type: resourcev1alpha1.PipelineResourceTypeImage},
+				}},
+			},
+		},
+	}
+
+	params, workspaces, results, warnings, err := ConvertTask(context.Background(), in)
+	if err != nil {
+		t.Fatalf("ConvertTask() returned error: %v", err)
+	}
+	if len(params) != 0 {
+		t.Errorf("ConvertTask() params = %v, want none for this fixture", params)
+	}
+	if len(workspaces) != 1 || workspaces[0].Name != "source" {
+		t.Errorf("ConvertTask() workspaces = %v, want one workspace named %q", workspaces, "source")
+	}
+	if len(results) != 2 {
+		t.Errorf("ConvertTask() results = %v, want 2 (IMAGE_URL/IMAGE_DIGEST equivalents)", results)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("ConvertTask() warnings = %v, want 1 (for the image resource; git converts without one)", warnings)
+	}
+}
+
+func TestConvertTask_UnknownResourceType(t *testing.T) {
+	in := &v1beta1.Task{
+		Spec: v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs: []v1beta1.TaskResource{{
+					ResourceDeclaration: v1beta1.ResourceDeclaration{Name: "cluster", Type: "cluster"},
+				}},
+			},
+		},
+	}
+	if _, _, _, _, err := ConvertTask(context.Background(), in); err == nil {
+		t.Error("ConvertTask() expected an error for an unsupported resource type, got none")
+	}
+}
+
+func TestConvert_UnknownResourceType(t *testing.T) {
+	in := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Resources: &v1beta1.TaskRunResources{
+				Inputs: []v1beta1.TaskResourceBinding{{
+					PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+						Name:         "cluster-resource",
+						ResourceSpec: &resourcev1alpha1.PipelineResourceSpec{Type: "cluster"},
+					},
+				}},
+			},
+		},
+	}
+	if _, _, err := Convert(context.Background(), in); err == nil {
+		t.Error("Convert() expected an error for an unsupported resource type, got none")
+	}
+}
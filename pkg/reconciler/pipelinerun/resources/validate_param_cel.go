@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// paramCELEnv is the CEL environment every param validation expression is
+// compiled against. self is typed dynamically because its Go shape
+// (string, []string, or map[string]string) depends on the param's
+// declared Type.
+var paramCELEnv = mustNewParamCELEnv()
+
+func mustNewParamCELEnv() *cel.Env {
+	env, err := cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		panic(fmt.Sprintf("failed to construct the param CEL environment: %v", err))
+	}
+	return env
+}
+
+// CELProgramCache compiles and caches CEL programs for param validation
+// expressions, keyed by expression source, so a reconciler validating many
+// runs of the same Pipeline or Task compiles each expression only once.
+// It's safe for concurrent use.
+type CELProgramCache struct {
+	mu    sync.Mutex
+	cache map[string]cel.Program
+}
+
+// NewCELProgramCache returns an empty CELProgramCache. A reconciler can
+// create one and reuse it across ValidateParamCEL calls instead of
+// calling the package-level ValidateParamCEL, which compiles into a single
+// cache shared by every caller in the process.
+func NewCELProgramCache() *CELProgramCache {
+	return &CELProgramCache{cache: map[string]cel.Program{}}
+}
+
+func (c *CELProgramCache) compile(expression string) (cel.Program, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prg, ok := c.cache[expression]; ok {
+		return prg, nil
+	}
+	ast, iss := paramCELEnv.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	prg, err := paramCELEnv.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	c.cache[expression] = prg
+	return prg, nil
+}
+
+// ValidateParamCEL evaluates every CEL rule declared on specs (ParamSpec's
+// Validation, and, for object params, each property's CEL) against the
+// matching value in params, binding self to that value or, for an object
+// property, to that key's string value. It collects every rule violation
+// across every param rather than stopping at the first one, so a caller
+// reporting the result can name every offending param at once, and
+// returns them joined via errors.Join (nil if none). A param with no
+// matching spec, or a spec with no rules, is skipped; required/default
+// handling belongs to ValidateRequiredParametersProvided.
+func (c *CELProgramCache) ValidateParamCEL(specs []v1.ParamSpec, params []v1.Param) error {
+	byName := make(map[string]v1.Param, len(params))
+	for _, p := range params {
+		byName[p.Name] = p
+	}
+
+	var errs []error
+	for _, spec := range specs {
+		p, ok := byName[spec.Name]
+		if !ok {
+			continue
+		}
+
+		self, err := celSelf(p.Value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("param %q: %w", spec.Name, err))
+			continue
+		}
+		for _, rule := range spec.Validation {
+			if err := c.evalRule(spec.Name, rule, self); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if spec.Type != v1.ParamTypeObject {
+			continue
+		}
+		for key, ps := range spec.Properties {
+			if ps.CEL == "" {
+				continue
+			}
+			v, present := p.Value.ObjectVal[key]
+			if !present {
+				continue
+			}
+			rule := v1.ParamValidationRule{Expression: ps.CEL}
+			if err := c.evalRule(fmt.Sprintf("%s.%s", spec.Name, key), rule, v); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *CELProgramCache) evalRule(paramName string, rule v1.ParamValidationRule, self interface{}) error {
+	prg, err := c.compile(rule.Expression)
+	if err != nil {
+		return fmt.Errorf("param %q: failed to compile CEL expression %q: %w", paramName, rule.Expression, err)
+	}
+	out, _, err := prg.Eval(map[string]interface{}{"self": self})
+	if err != nil {
+		return fmt.Errorf("param %q: CEL expression %q failed to evaluate: %w", paramName, rule.Expression, err)
+	}
+	ok, isBool := out.Value().(bool)
+	if !isBool {
+		return fmt.Errorf("param %q: CEL expression %q must evaluate to a bool, got %T", paramName, rule.Expression, out.Value())
+	}
+	if !ok {
+		msg := rule.Message
+		if msg == "" {
+			msg = fmt.Sprintf("failed CEL validation %q", rule.Expression)
+		}
+		return fmt.Errorf("param %q: %s", paramName, msg)
+	}
+	return nil
+}
+
+// celSelf converts a ParamValue to the Go value bound as self, matching
+// cel.DynType: a string, a []string, or a map[string]string depending on
+// the param's declared Type.
+func celSelf(v v1.ParamValue) (interface{}, error) {
+	switch v.Type {
+	case v1.ParamTypeString:
+		return v.StringVal, nil
+	case v1.ParamTypeArray:
+		return v.ArrayVal, nil
+	case v1.ParamTypeObject:
+		return v.ObjectVal, nil
+	default:
+		return nil, fmt.Errorf("unsupported param type %q for CEL validation", v.Type)
+	}
+}
+
+// defaultCELCache backs the package-level ValidateParamCEL convenience
+// function below.
+var defaultCELCache = NewCELProgramCache()
+
+// ValidateParamCEL is ValidateParamCEL on a shared, package-level
+// CELProgramCache. Call sites that validate many runs of the same
+// Pipeline should hold their own CELProgramCache instead, to control its
+// lifetime explicitly.
+func ValidateParamCEL(specs []v1.ParamSpec, params []v1.Param) error {
+	return defaultCELCache.ValidateParamCEL(specs, params)
+}
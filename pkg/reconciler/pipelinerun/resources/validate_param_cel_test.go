@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestValidateParamCEL_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pp   []v1.ParamSpec
+		prp  []v1.Param
+	}{{
+		name: "string param satisfies pattern rule",
+		pp: []v1.ParamSpec{{
+			Name: "name", Type: v1.ParamTypeString,
+			Validation: []v1.ParamValidationRule{{Expression: `self.matches('^[a-z0-9-]+$')`}},
+		}},
+		prp: []v1.Param{{Name: "name", Value: *v1.NewStructuredValues("my-name-1")}},
+	}, {
+		name: "array param satisfies size rule",
+		pp: []v1.ParamSpec{{
+			Name: "items", Type: v1.ParamTypeArray,
+			Validation: []v1.ParamValidationRule{{Expression: `size(self) > 0`}},
+		}},
+		prp: []v1.Param{{Name: "items", Value: *v1.NewStructuredValues("a", "b")}},
+	}, {
+		name: "object property satisfies CEL rule",
+		pp: []v1.ParamSpec{{
+			Name: "image", Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"tag": {Type: "string", CEL: `size(self) < 128`},
+			},
+		}},
+		prp: []v1.Param{{Name: "image", Value: *v1.NewObject(map[string]string{"tag": "latest"})}},
+	}, {
+		name: "param with no matching spec is skipped",
+		pp:   []v1.ParamSpec{},
+		prp:  []v1.Param{{Name: "unused", Value: *v1.NewStructuredValues("anything")}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateParamCEL(tc.pp, tc.prp); err != nil {
+				t.Errorf("ValidateParamCEL() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateParamCEL_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pp   []v1.ParamSpec
+		prp  []v1.Param
+	}{{
+		name: "string param fails pattern rule",
+		pp: []v1.ParamSpec{{
+			Name: "name", Type: v1.ParamTypeString,
+			Validation: []v1.ParamValidationRule{{Expression: `self.matches('^[a-z0-9-]+$')`, Message: "must be a valid DNS label"}},
+		}},
+		prp: []v1.Param{{Name: "name", Value: *v1.NewStructuredValues("Not Valid!")}},
+	}, {
+		name: "array param fails size rule",
+		pp: []v1.ParamSpec{{
+			Name: "items", Type: v1.ParamTypeArray,
+			Validation: []v1.ParamValidationRule{{Expression: `size(self) > 0`}},
+		}},
+		prp: []v1.Param{{Name: "items", Value: *v1.NewStructuredValues()}},
+	}, {
+		name: "object property fails CEL rule",
+		pp: []v1.ParamSpec{{
+			Name: "image", Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"tag": {Type: "string", CEL: `size(self) < 4`},
+			},
+		}},
+		prp: []v1.Param{{Name: "image", Value: *v1.NewObject(map[string]string{"tag": "latest"})}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateParamCEL(tc.pp, tc.prp); err == nil {
+				t.Errorf("Expected to see error when validating invalid param CEL rules but saw none")
+			}
+		})
+	}
+}
+
+// TestValidateParamCEL_CollectsAllViolations checks that a failure on one
+// param doesn't stop rules on a later param from being evaluated, so a
+// caller reporting the error can name every offending param at once.
+func TestValidateParamCEL_CollectsAllViolations(t *testing.T) {
+	pp := []v1.ParamSpec{{
+		Name: "name", Type: v1.ParamTypeString,
+		Validation: []v1.ParamValidationRule{{Expression: `self.matches('^[a-z0-9-]+$')`}},
+	}, {
+		Name: "items", Type: v1.ParamTypeArray,
+		Validation: []v1.ParamValidationRule{{Expression: `size(self) > 0`}},
+	}}
+	prp := []v1.Param{
+		{Name: "name", Value: *v1.NewStructuredValues("Not Valid!")},
+		{Name: "items", Value: *v1.NewStructuredValues()},
+	}
+	err := ValidateParamCEL(pp, prp)
+	if err == nil {
+		t.Fatal("ValidateParamCEL() = nil, want an error naming both violations")
+	}
+	for _, want := range []string{`"name"`, `"items"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateParamCEL() error %q does not mention %s", err, want)
+		}
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != 2 {
+		t.Errorf("ValidateParamCEL() error = %v, want errors.Join of exactly 2 violations", err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// ResolvedDependenciesResultName is the name of the result
+// ResolvedDependenciesResult renders for the ResolverRefs a
+// TaskRun/PipelineRun resolved. Its shape matches the resource
+// descriptors chains and other provenance consumers expect: a URI, an
+// algorithm-keyed digest map, and the resolver params echoed back as
+// annotations.
+const ResolvedDependenciesResultName = "tekton.dev/resolved-dependencies"
+
+// ResolvedDependency is a single entry of the resolved-dependencies result,
+// one per resolved Task or Pipeline ref (including nested Task refs within
+// a resolved Pipeline).
+type ResolvedDependency struct {
+	URI         string            `json:"uri"`
+	Digest      map[string]string `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// NewResolvedDependency computes the ResolvedDependency for a ResolverRef
+// whose resolver returned raw. The digest is the sha256 of the raw bytes
+// the resolver returned, and the URI is reconstructed from the resolver's
+// params using the conventions each in-tree resolver already documents
+// (e.g. git+<url>@<revision>#<pathInRepo>, oci://<bundle>).
+func NewResolvedDependency(ref v1.ResolverRef, raw []byte) ResolvedDependency {
+	sum := sha256.Sum256(raw)
+	annotations := make(map[string]string, len(ref.Params)+1)
+	annotations["resolver"] = ref.Resolver
+	for _, p := range ref.Params {
+		annotations[p.Name] = p.Value.StringVal
+	}
+	return ResolvedDependency{
+		URI:         reconstructURI(ref),
+		Digest:      map[string]string{"sha256": hex.EncodeToString(sum[:])},
+		Annotations: annotations,
+	}
+}
+
+// reconstructURI builds the resource descriptor URI for a resolved ref
+// from its resolver name and params, following the same conventions as
+// the resolvers themselves.
+func reconstructURI(ref v1.ResolverRef) string {
+	params := make(map[string]string, len(ref.Params))
+	for _, p := range ref.Params {
+		params[p.Name] = p.Value.StringVal
+	}
+	switch ref.Resolver {
+	case "git":
+		uri := fmt.Sprintf("git+%s@%s", params["url"], params["revision"])
+		if path := params["pathInRepo"]; path != "" {
+			uri += "#" + path
+		}
+		return uri
+	case "bundles":
+		return "oci://" + params["bundle"]
+	default:
+		return fmt.Sprintf("%s://%s", ref.Resolver, params["name"])
+	}
+}
+
+// ResolvedDependenciesResult renders the accumulated ResolvedDependency
+// entries as the v1.TaskRunResult the reconciler appends to TaskRunStatus /
+// PipelineRunStatus once at least one ResolverRef was resolved. No
+// reconciler calls this yet, since this checkout has no TaskRun/PipelineRun
+// reconcile loop to hang it off of.
+func ResolvedDependenciesResult(deps []ResolvedDependency) v1.TaskRunResult {
+	array := make([]map[string]string, 0, len(deps))
+	for _, d := range deps {
+		entry := map[string]string{"uri": d.URI}
+		for alg, hex := range d.Digest {
+			entry["digest."+alg] = hex
+		}
+		for k, v := range d.Annotations {
+			entry["annotation."+k] = v
+		}
+		array = append(array, entry)
+	}
+	return v1.TaskRunResult{
+		Name:  ResolvedDependenciesResultName,
+		Type:  v1.ParamTypeArray,
+		Value: *v1.NewStructuredValues(flattenDigests(array)...),
+	}
+}
+
+// flattenDigests renders each resolved dependency - its uri, its
+// "digest.<alg>" entries, and its "annotation.<key>" entries - as a single
+// "uri=... digest.alg=hex ... annotation.key=value ..." string, since
+// v1.Result array values carry plain strings rather than nested objects.
+// The digest./annotation. prefixes keep the two namespaces from colliding
+// when a resolver happens to pass an annotation named like a digest
+// algorithm (or vice versa), and let a consumer parsing the string back
+// out tell the two apart unambiguously. Keys other than uri are sorted
+// before being concatenated so the result is reproducible across
+// reconciles instead of churning on Go's randomized map iteration order.
+func flattenDigests(entries []map[string]string) []string {
+	out := make([]string, 0, len(entries))
+	for _, e := range entries {
+		keys := make([]string, 0, len(e)-1)
+		for k := range e {
+			if k == "uri" {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		s := "uri=" + e["uri"]
+		for _, k := range keys {
+			s += " " + k + "=" + e[k]
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestNewResolvedDependency_Git(t *testing.T) {
+	ref := v1.ResolverRef{
+		Resolver: "git",
+		Params: []v1.Param{
+			{Name: "url", Value: *v1.NewStructuredValues("https://github.com/tektoncd/catalog.git")},
+			{Name: "revision", Value: *v1.NewStructuredValues("main")},
+			{Name: "pathInRepo", Value: *v1.NewStructuredValues("task/git-clone/0.9/git-clone.yaml")},
+		},
+	}
+	raw := []byte("apiVersion: tekton.dev/v1\nkind: Task\n")
+
+	got := NewResolvedDependency(ref, raw)
+
+	wantURI := "git+https://github.com/tektoncd/catalog.git@main#task/git-clone/0.9/git-clone.yaml"
+	if got.URI != wantURI {
+		t.Errorf("URI = %q, want %q", got.URI, wantURI)
+	}
+	if got.Annotations["resolver"] != "git" {
+		t.Errorf("Annotations[resolver] = %q, want %q", got.Annotations["resolver"], "git")
+	}
+	wantSum := sha256Hex(raw)
+	if got.Digest["sha256"] != wantSum {
+		t.Errorf("Digest[sha256] = %q, want %q", got.Digest["sha256"], wantSum)
+	}
+}
+
+func TestNewResolvedDependency_Bundles(t *testing.T) {
+	ref := v1.ResolverRef{
+		Resolver: "bundles",
+		Params: []v1.Param{
+			{Name: "bundle", Value: *v1.NewStructuredValues("gcr.io/example/catalog:latest")},
+			{Name: "name", Value: *v1.NewStructuredValues("git-clone")},
+		},
+	}
+	got := NewResolvedDependency(ref, []byte("task"))
+	if want := "oci://gcr.io/example/catalog:latest"; got.URI != want {
+		t.Errorf("URI = %q, want %q", got.URI, want)
+	}
+}
+
+func sha256Hex(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestResolvedDependenciesResult_PreservesAnnotations(t *testing.T) {
+	ref := v1.ResolverRef{
+		Resolver: "git",
+		Params: []v1.Param{
+			{Name: "url", Value: *v1.NewStructuredValues("https://github.com/tektoncd/catalog.git")},
+			{Name: "revision", Value: *v1.NewStructuredValues("main")},
+		},
+	}
+	dep := NewResolvedDependency(ref, []byte("task"))
+
+	result := ResolvedDependenciesResult([]ResolvedDependency{dep})
+
+	if got, want := result.Name, ResolvedDependenciesResultName; got != want {
+		t.Errorf("Name = %q, want %q", got, want)
+	}
+	if len(result.Value.ArrayVal) != 1 {
+		t.Fatalf("Value.ArrayVal has %d entries, want 1", len(result.Value.ArrayVal))
+	}
+	entry := result.Value.ArrayVal[0]
+	for _, want := range []string{
+		"uri=" + dep.URI,
+		"digest.sha256=" + dep.Digest["sha256"],
+		"annotation.resolver=git",
+		"annotation.url=https://github.com/tektoncd/catalog.git",
+		"annotation.revision=main",
+	} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("entry %q missing %q", entry, want)
+		}
+	}
+}
+
+func TestResolvedDependenciesResult_Reproducible(t *testing.T) {
+	dep := ResolvedDependency{
+		URI:         "git+https://github.com/tektoncd/catalog.git",
+		Digest:      map[string]string{"sha256": "deadbeef", "sha512": "cafef00d"},
+		Annotations: map[string]string{"resolver": "git", "url": "https://github.com/tektoncd/catalog.git", "revision": "main"},
+	}
+
+	first := ResolvedDependenciesResult([]ResolvedDependency{dep}).Value.ArrayVal[0]
+	for i := 0; i < 10; i++ {
+		got := ResolvedDependenciesResult([]ResolvedDependency{dep}).Value.ArrayVal[0]
+		if got != first {
+			t.Fatalf("ResolvedDependenciesResult() is not reproducible: got %q, want %q", got, first)
+		}
+	}
+}
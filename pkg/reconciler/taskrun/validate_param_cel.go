@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinerunresources "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+)
+
+// taskRunCELCache backs ValidateParamCEL for TaskRun reconciliation,
+// reusing pipelinerun/resources' generic CEL machinery (it operates on
+// v1.ParamSpec/v1.Param and isn't actually Pipeline-specific) so each
+// validation expression is compiled once per process rather than once per
+// TaskRun.
+var taskRunCELCache = pipelinerunresources.NewCELProgramCache()
+
+// ValidateParamCEL is ValidateResolvedTask's counterpart for content
+// validation: it evaluates each declared ParamSpec.Validation rule (and,
+// for object params, each property's PropertySpec.CEL rule) against the
+// matching value in params, returning every violation joined via
+// errors.Join (nil if none) rather than stopping at the first param
+// whose value fails.
+func ValidateParamCEL(params []v1.Param, matrix *v1.Matrix, rtr *v1.TaskSpec) error {
+	if rtr == nil {
+		return nil
+	}
+	all := params
+	if matrix != nil {
+		all = append(append([]v1.Param{}, params...), matrix.Params...)
+	}
+	return taskRunCELCache.ValidateParamCEL(rtr.Params, all)
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2019 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+)
+
+// ValidationErrorReason categorizes why TaskRun/Task validation failed, so
+// callers (event recording, metrics) can key off a stable reason instead
+// of matching on error text.
+type ValidationErrorReason string
+
+const (
+	// ReasonUnexpectedParam means a param or matrix entry was provided
+	// that the Task does not declare.
+	ReasonUnexpectedParam ValidationErrorReason = "UnexpectedParam"
+	// ReasonMissingParam means a Task param without a default was not
+	// satisfied by either params or matrix.
+	ReasonMissingParam ValidationErrorReason = "MissingParam"
+	// ReasonParamTypeMismatch means a provided param's type doesn't match
+	// what the Task declares.
+	ReasonParamTypeMismatch ValidationErrorReason = "ParamTypeMismatch"
+	// ReasonMissingObjectKeys means an object result is missing one of
+	// its declared keys, or an object param's value breaks the schema
+	// declared on its properties (a missing required key, a failed
+	// format constraint, or an undeclared key where none is allowed).
+	ReasonMissingObjectKeys ValidationErrorReason = "MissingObjectKeys"
+	// ReasonInvalidOverride means a StepSpec/SidecarSpec override names a
+	// step or sidecar the Task doesn't define.
+	ReasonInvalidOverride ValidationErrorReason = "InvalidOverride"
+	// ReasonResultTypeMismatch means an emitted result's type doesn't
+	// match what the Task declares.
+	ReasonResultTypeMismatch ValidationErrorReason = "ResultTypeMismatch"
+	// ReasonParamCELFailed means a provided param's value failed one of
+	// its declared CEL validation rules.
+	ReasonParamCELFailed ValidationErrorReason = "ParamCELFailed"
+)
+
+// ValidationError is returned by ValidateResolvedTask and
+// validateTaskRunResults. It carries a stable Reason and the Field it
+// applies to, in addition to a human-readable Message, so callers can
+// react to the failure mode directly instead of parsing error strings.
+type ValidationError struct {
+	Reason  ValidationErrorReason
+	Field   string
+	Message string
+}
+
+var _ error = (*ValidationError)(nil)
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func newValidationError(reason ValidationErrorReason, field, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Reason: reason, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// ObjectKeyMissingError indicates an object param's value breaks the
+// schema declared on its properties: a required key is missing, a value
+// fails a format constraint (pattern, enum, min/max, length), or an
+// undeclared key is present where additional properties are disallowed.
+// It wraps the generic ValidationError so a
+// caller can retrieve it with errors.As instead of comparing Reason
+// against ReasonMissingObjectKeys off a bare ValidationError.
+type ObjectKeyMissingError struct {
+	*ValidationError
+}
+
+func newObjectKeyMissingError(field, format string, args ...interface{}) *ObjectKeyMissingError {
+	return &ObjectKeyMissingError{newValidationError(ReasonMissingObjectKeys, field, format, args...)}
+}
+
+// ParamValidationError indicates a param's value failed one of its
+// declared CEL validation rules. It wraps the generic ValidationError so
+// a caller can retrieve it with errors.As instead of comparing Reason
+// against ReasonParamCELFailed off a bare ValidationError.
+type ParamValidationError struct {
+	*ValidationError
+}
+
+func newParamValidationError(field, format string, args ...interface{}) *ParamValidationError {
+	return &ParamValidationError{newValidationError(ReasonParamCELFailed, field, format, args...)}
+}
+
+// isResultRef reports whether a param value is a reference to another
+// task's result (e.g. "$(results.foo[*])" or "$(tasks.foo.results.bar)"),
+// which is resolved later in the pipeline and so is exempt from the
+// Task's declared param type until then.
+func isResultRef(v v1.ParamValue) bool {
+	return strings.Contains(v.StringVal, "$(results.") || strings.Contains(v.StringVal, "$(tasks.")
+}
+
+// ValidateResolvedTask validates that the params and matrix supplied to a
+// TaskRun satisfy the Task it resolved to: every declared param without a
+// default is provided, provided values match their declared types (result
+// references are exempt, since they're not resolved yet), object params
+// carry all the keys the Task requires, matrix entries only target
+// string-typed params, and any CEL validation rules declared on the
+// Task's params accept the supplied values. It collects every violation
+// it finds rather than stopping at the first one, and returns them
+// joined via errors.Join (nil if none), so a caller reporting the result
+// can name every offending param at once.
+func ValidateResolvedTask(ctx context.Context, params []v1.Param, matrix *v1.Matrix, rtr *resources.ResolvedTask) error {
+	if rtr == nil || rtr.TaskSpec == nil {
+		return nil
+	}
+	specs := rtr.TaskSpec.Params
+
+	declared := make(map[string]v1.ParamSpec, len(specs))
+	for _, ps := range specs {
+		declared[ps.Name] = ps
+	}
+
+	allowExtra := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
+
+	var errs []error
+	for _, p := range params {
+		ps, ok := declared[p.Name]
+		if !ok {
+			if allowExtra {
+				continue
+			}
+			errs = append(errs, newValidationError(ReasonUnexpectedParam, p.Name, "param %q is not declared in the Task spec", p.Name))
+			continue
+		}
+		if p.Value.Type != ps.Type && !isResultRef(p.Value) {
+			errs = append(errs, newValidationError(ReasonParamTypeMismatch, p.Name, "param %q must be of type %s, not %s", p.Name, ps.Type, p.Value.Type))
+			continue
+		}
+		if ps.Type == v1.ParamTypeObject {
+			if err := validateObjectKeys(ps.Name, ps.Properties, ps.AdditionalProperties, ps.Default, &p.Value); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if matrix != nil {
+		for _, p := range matrix.Params {
+			ps, ok := declared[p.Name]
+			if !ok {
+				if allowExtra {
+					continue
+				}
+				errs = append(errs, newValidationError(ReasonUnexpectedParam, p.Name, "matrix param %q is not declared in the Task spec", p.Name))
+				continue
+			}
+			if ps.Type != v1.ParamTypeString {
+				errs = append(errs, newValidationError(ReasonParamTypeMismatch, p.Name, "matrix param %q must reference a string-typed Task param, not %s", p.Name, ps.Type))
+			}
+		}
+	}
+
+	for _, ps := range specs {
+		if ps.Default != nil {
+			continue
+		}
+		if paramHas(params, ps.Name) || matrixHas(matrix, ps.Name) {
+			continue
+		}
+		errs = append(errs, newValidationError(ReasonMissingParam, ps.Name, "required param %q was not provided", ps.Name))
+	}
+
+	if err := ValidateParamCEL(params, matrix, rtr.TaskSpec); err != nil {
+		errs = append(errs, newParamValidationError("", "%s", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func paramHas(params []v1.Param, name string) bool {
+	for _, p := range params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func matrixHas(matrix *v1.Matrix, name string) bool {
+	if matrix == nil {
+		return false
+	}
+	for _, p := range matrix.Params {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateObjectKeys checks the object param's merged value (the provided
+// value layered over def, the ParamSpec's default) against the schema
+// declared on properties: every declared key must resolve to a value, and
+// any format constraints (pattern, enum, min/max, length) declared on
+// individual PropertySpecs must be satisfied. additionalProperties mirrors
+// the ParamSpec field of the same name; a nil or true value allows keys
+// outside properties, matching the object param's pre-existing default.
+func validateObjectKeys(name string, properties map[string]v1.PropertySpec, additionalProperties *bool, def *v1.ParamValue, value *v1.ParamValue) error {
+	if value == nil || value.Type != v1.ParamTypeObject {
+		return nil
+	}
+	merged := make(map[string]string, len(value.ObjectVal))
+	if def != nil {
+		for k, v := range def.ObjectVal {
+			merged[k] = v
+		}
+	}
+	for k, v := range value.ObjectVal {
+		merged[k] = v
+	}
+	additionalPropertiesAllowed := additionalProperties == nil || *additionalProperties
+	if fe := v1.ValidateObjectValue(name, properties, additionalPropertiesAllowed, merged); fe != nil {
+		return newObjectKeyMissingError(name, "%s", fe.Error())
+	}
+	return nil
+}
+
+// validateOverrides checks that every StepSpec/SidecarSpec override in trs
+// names a step or sidecar actually defined in ts.
+func validateOverrides(ts *v1.TaskSpec, trs *v1.TaskRunSpec) error {
+	stepNames := make(map[string]bool, len(ts.Steps))
+	for _, s := range ts.Steps {
+		stepNames[s.Name] = true
+	}
+	for _, o := range trs.StepSpecs {
+		if !stepNames[o.Name] {
+			return newValidationError(ReasonInvalidOverride, o.Name, "no step named %q is defined by the Task", o.Name)
+		}
+	}
+
+	sidecarNames := make(map[string]bool, len(ts.Sidecars))
+	for _, s := range ts.Sidecars {
+		sidecarNames[s.Name] = true
+	}
+	for _, o := range trs.SidecarSpecs {
+		if !sidecarNames[o.Name] {
+			return newValidationError(ReasonInvalidOverride, o.Name, "no sidecar named %q is defined by the Task", o.Name)
+		}
+	}
+	return nil
+}
+
+// validateTaskRunResults checks that every result a TaskRun actually
+// produced matches the type (and, for objects, the required keys) that
+// the Task declares for a result of that name. Declarations are read from
+// tr.Spec.TaskSpec.Results when present, falling back to rtr (the
+// resolved Task's spec) when the TaskRun didn't embed its own TaskSpec or
+// its embedded TaskSpec declares no results.
+func validateTaskRunResults(tr *v1.TaskRun, rtr *v1.TaskSpec) error {
+	var declared []v1.TaskResult
+	if tr.Spec.TaskSpec != nil {
+		declared = tr.Spec.TaskSpec.Results
+	}
+	if len(declared) == 0 && rtr != nil {
+		declared = rtr.Results
+	}
+
+	byName := make(map[string]v1.TaskResult, len(declared))
+	for _, r := range declared {
+		byName[r.Name] = r
+	}
+
+	for _, actual := range tr.Status.Results {
+		want, ok := byName[actual.Name]
+		if !ok {
+			continue
+		}
+		if actual.Type != want.Type {
+			return newValidationError(ReasonResultTypeMismatch, actual.Name, "result %q has type %s, want %s", actual.Name, actual.Type, want.Type)
+		}
+		if want.Type == v1.ResultsTypeObject {
+			for key := range want.Properties {
+				if _, ok := actual.Value.ObjectVal[key]; !ok {
+					return newValidationError(ReasonMissingObjectKeys, actual.Name, "result %q is missing required key %q", actual.Name, key)
+				}
+			}
+		}
+	}
+
+	return nil
+}
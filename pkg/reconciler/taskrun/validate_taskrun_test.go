@@ -18,6 +18,7 @@ package taskrun
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -243,6 +244,47 @@ func TestValidateResolvedTask_InvalidParams(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("missing object param keys returns an ObjectKeyMissingError", func(t *testing.T) {
+		err := ValidateResolvedTask(ctx, tcs[3].params, tcs[3].matrix, tcs[3].rtr)
+		var keyErr *ObjectKeyMissingError
+		if !errors.As(err, &keyErr) {
+			t.Fatalf("ValidateResolvedTask() = %v, want an error wrapping *ObjectKeyMissingError", err)
+		}
+	})
+}
+
+// TestValidateResolvedTask_CollectsAllViolations checks that a failed
+// param doesn't stop an unrelated matrix violation, or the missing
+// required param "bar", from also being reported, so a caller can see
+// every offending param at once rather than fixing them one error at a
+// time.
+func TestValidateResolvedTask_CollectsAllViolations(t *testing.T) {
+	ctx := context.Background()
+	rtr := &resources.ResolvedTask{
+		TaskSpec: &v1.TaskSpec{
+			Params: []v1.ParamSpec{
+				{Name: "foo", Type: v1.ParamTypeString},
+				{Name: "bar", Type: v1.ParamTypeArray},
+			},
+		},
+	}
+	params := []v1.Param{{
+		Name:  "foo",
+		Value: *v1.NewStructuredValues("a", "b"), // wrong type
+	}}
+	matrix := &v1.Matrix{Params: []v1.Param{{
+		Name:  "barfoo", // not declared
+		Value: *v1.NewStructuredValues("bar", "foo"),
+	}}}
+	err := ValidateResolvedTask(ctx, params, matrix, rtr)
+	if err == nil {
+		t.Fatal("ValidateResolvedTask() = nil, want an error naming all three violations")
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != 3 {
+		t.Errorf("ValidateResolvedTask() error = %v, want errors.Join of exactly 3 violations", err)
+	}
 }
 
 func TestValidateOverrides(t *testing.T) {
@@ -362,6 +404,33 @@ func TestValidateResult(t *testing.T) {
 			Results: []v1.TaskResult{},
 		},
 		wantErr: false,
+	}, {
+		name: "valid results via taskRef, nil inline TaskSpec",
+		tr: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				TaskRef: &v1.TaskRef{Name: "a-task"},
+			},
+			Status: v1.TaskRunStatus{
+				TaskRunStatusFields: v1.TaskRunStatusFields{
+					Results: []v1.TaskRunResult{
+						{
+							Name:  "string-result",
+							Type:  v1.ResultsTypeString,
+							Value: *v1.NewStructuredValues("hello"),
+						},
+					},
+				},
+			},
+		},
+		rtr: &v1.TaskSpec{
+			Results: []v1.TaskResult{
+				{
+					Name: "string-result",
+					Type: v1.ResultsTypeString,
+				},
+			},
+		},
+		wantErr: false,
 	}, {
 		name: "valid taskspec results",
 		tr: &v1.TaskRun{
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"flag"
+	"os"
+	"testing"
+	"time"
+)
+
+// ReportPath names the file conformance test records are flushed to on
+// Flush. Set via -conformance.report=path; left empty, Flush is a no-op
+// so running the suite without the flag behaves exactly as before.
+var ReportPath = flag.String("conformance.report", "", "write a JUnit XML and JSON conformance report to this path (without extension)")
+
+// T wraps *testing.T so a test can annotate itself with the spec clause
+// it exercises (c.Section(...)) and have every subsequent t.Error/Errorf
+// recorded against that clause, in addition to being reported by go test
+// as usual.
+type T struct {
+	*testing.T
+	report  *Report
+	section string
+	class   FieldClass
+	fields  []string
+	start   time.Time
+}
+
+// Wrap returns a T that records results from t into r.
+func Wrap(t *testing.T, r *Report) *T {
+	t.Helper()
+	c := &T{T: t, report: r, start: time.Now()}
+	t.Cleanup(func() {
+		if c.section == "" {
+			return
+		}
+		r.Add(Record{
+			TestName: t.Name(),
+			Section:  c.section,
+			Class:    c.class,
+			Fields:   c.fields,
+			Passed:   !t.Failed(),
+			Duration: time.Since(c.start),
+		})
+	})
+	return c
+}
+
+// Section annotates the test with the spec section it exercises, whether
+// that section is REQUIRED or RECOMMENDED, and the concrete status
+// field(s) it probes, e.g.:
+//
+//	c.Section("TaskRun/StepState/Terminated", report.Required, "status.steps[].terminated.exitCode")
+func (c *T) Section(section string, class FieldClass, fields ...string) {
+	c.T.Helper()
+	c.section = section
+	c.class = class
+	c.fields = fields
+}
+
+// Flush writes r as JUnit XML and JSON to *ReportPath + ".xml"/".json".
+// It's a no-op if -conformance.report wasn't set. Intended to be called
+// from TestMain after m.Run().
+func Flush(r *Report) error {
+	if *ReportPath == "" {
+		return nil
+	}
+	xmlFile, err := os.Create(*ReportPath + ".xml")
+	if err != nil {
+		return err
+	}
+	defer xmlFile.Close()
+	if err := r.WriteJUnit(xmlFile); err != nil {
+		return err
+	}
+
+	jsonFile, err := os.Create(*ReportPath + ".json")
+	if err != nil {
+		return err
+	}
+	defer jsonFile.Close()
+	return r.WriteJSON(jsonFile)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report turns conformance test results into machine-readable
+// artifacts. Go test output alone only tells a human pass/fail; a vendor
+// producing a self-certification artifact also needs to know which spec
+// clause each test exercised, whether that clause is REQUIRED or
+// RECOMMENDED, and which concrete status fields were probed. Package
+// report captures that alongside the usual pass/fail so it can be
+// emitted as both JUnit XML (for CI ingest) and a JSON summary keyed by
+// conformance clause.
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FieldClass says whether a conformance clause is mandatory for a vendor
+// to claim conformance, or merely recommended.
+type FieldClass string
+
+const (
+	// Required marks a clause every conformant vendor must implement.
+	Required FieldClass = "REQUIRED"
+	// Recommended marks a clause vendors are encouraged, but not
+	// required, to implement.
+	Recommended FieldClass = "RECOMMENDED"
+)
+
+// Record is one test's contribution to the report: the spec section it
+// exercised, the concrete fields it probed (e.g.
+// "status.steps[].terminated.exitCode"), and its outcome.
+type Record struct {
+	TestName string
+	Section  string
+	Class    FieldClass
+	Fields   []string
+	Passed   bool
+	Failure  string
+	Duration time.Duration
+}
+
+// Report accumulates Records across a test binary run and renders them
+// as JUnit XML or JSON. It's safe for concurrent use so tests run with
+// -parallel can all report through the same instance.
+type Report struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// New returns an empty Report.
+func New() *Report {
+	return &Report{}
+}
+
+// Add appends rec to the report.
+func (r *Report) Add(rec Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+}
+
+// Records returns a copy of the records accumulated so far.
+func (r *Report) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Record, len(r.records))
+	copy(out, r.records)
+	return out
+}
+
+// junitTestsuite and junitTestcase model just enough of the JUnit XML
+// schema for CI systems to ingest pass/fail and failure messages.
+type junitTestsuite struct {
+	XMLName   xml.Name       `xml:"testsuite"`
+	Name      string         `xml:"name,attr"`
+	Tests     int            `xml:"tests,attr"`
+	Failures  int            `xml:"failures,attr"`
+	Testcases []junitTestcase `xml:"testcase"`
+}
+
+type junitTestcase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit renders the accumulated records as a JUnit XML testsuite.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	records := r.Records()
+	suite := junitTestsuite{Name: "conformance", Tests: len(records)}
+	for _, rec := range records {
+		tc := junitTestcase{
+			Name:      rec.TestName,
+			Classname: rec.Section,
+			Time:      strconv.FormatFloat(rec.Duration.Seconds(), 'f', 3, 64),
+		}
+		if !rec.Passed {
+			suite.Failures++
+			tc.Failure = &junitFailure{Message: rec.Failure, Text: rec.Failure}
+		}
+		suite.Testcases = append(suite.Testcases, tc)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(suite)
+}
+
+// clauseSummary is the JSON representation of one conformance clause's
+// aggregate result, keyed by Section in the JSON summary.
+type clauseSummary struct {
+	Class  FieldClass `json:"class"`
+	Fields []string   `json:"fields"`
+	Tests  []string   `json:"tests"`
+	Passed bool       `json:"passed"`
+}
+
+// WriteJSON renders the accumulated records as a JSON summary keyed by
+// conformance clause (Record.Section), so a vendor can see at a glance
+// which REQUIRED clauses it satisfies.
+func (r *Report) WriteJSON(w io.Writer) error {
+	records := r.Records()
+	summary := make(map[string]*clauseSummary, len(records))
+	order := make([]string, 0, len(records))
+	for _, rec := range records {
+		cs, ok := summary[rec.Section]
+		if !ok {
+			cs = &clauseSummary{Class: rec.Class, Passed: true}
+			summary[rec.Section] = cs
+			order = append(order, rec.Section)
+		}
+		cs.Fields = appendUnique(cs.Fields, rec.Fields...)
+		cs.Tests = append(cs.Tests, rec.TestName)
+		if !rec.Passed {
+			cs.Passed = false
+		}
+	}
+	ordered := make(map[string]*clauseSummary, len(summary))
+	for _, section := range order {
+		ordered[section] = summary[section]
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(ordered)
+}
+
+func appendUnique(have []string, add ...string) []string {
+	seen := make(map[string]bool, len(have))
+	for _, f := range have {
+		seen[f] = true
+	}
+	for _, f := range add {
+		if !seen[f] {
+			have = append(have, f)
+			seen[f] = true
+		}
+	}
+	return have
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportWriteJUnit(t *testing.T) {
+	r := New()
+	r.Add(Record{TestName: "TestFoo", Section: "TaskRun/Result", Class: Required, Passed: true, Duration: time.Second})
+	r.Add(Record{TestName: "TestBar", Section: "TaskRun/Sidecar", Class: Recommended, Passed: false, Failure: "boom", Duration: time.Second})
+
+	var buf bytes.Buffer
+	if err := r.WriteJUnit(&buf); err != nil {
+		t.Fatalf("WriteJUnit() = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `tests="2"`) || !strings.Contains(out, `failures="1"`) {
+		t.Errorf("WriteJUnit() output missing expected counts: %s", out)
+	}
+	if !strings.Contains(out, "boom") {
+		t.Errorf("WriteJUnit() output missing failure message: %s", out)
+	}
+}
+
+func TestReportWriteJSON(t *testing.T) {
+	r := New()
+	r.Add(Record{TestName: "TestFoo", Section: "TaskRun/Result", Class: Required, Fields: []string{"status.results"}, Passed: true})
+	r.Add(Record{TestName: "TestFoo2", Section: "TaskRun/Result", Class: Required, Fields: []string{"status.results"}, Passed: false})
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"TaskRun/Result"`) {
+		t.Errorf("WriteJSON() missing clause key: %s", out)
+	}
+	if !strings.Contains(out, `"passed": false`) {
+		t.Errorf("WriteJSON() clause should be failed once any test in it fails: %s", out)
+	}
+}
+
+func TestTSection(t *testing.T) {
+	r := New()
+
+	t.Run("records on cleanup", func(t *testing.T) {
+		c := Wrap(t, r)
+		c.Section("TaskRun/Result", Required, "status.results[].value")
+	})
+
+	records := r.Records()
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	if records[0].Section != "TaskRun/Result" || !records[0].Passed {
+		t.Errorf("unexpected record: %+v", records[0])
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testcase provides a typed builder for the conformance suite's
+// TaskRun scenarios, replacing hand-formatted fmt.Sprintf YAML literals
+// with composable Go. TaskRunCase constructs a real v1.TaskRun object,
+// which the suite serializes once to submit via ProcessAndSendToTekton.
+// Only TaskRuns are covered: there is no PipelineRun builder, and no
+// With* call for matrix, when expressions or retries.
+package testcase
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TaskRunCase builds a v1.TaskRun for submission to the conformance
+// harness. Use NewTaskRunCase and chain With* calls; call YAML to
+// serialize the result.
+type TaskRunCase struct {
+	tr *v1.TaskRun
+}
+
+// NewTaskRunCase returns a TaskRunCase for a TaskRun named name with an
+// empty inline TaskSpec.
+func NewTaskRunCase(name string) *TaskRunCase {
+	return &TaskRunCase{tr: &v1.TaskRun{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.TaskRunSpec{TaskSpec: &v1.TaskSpec{}},
+	}}
+}
+
+// WithAPIVersion overrides the default tekton.dev/v1 apiVersion, e.g. to
+// submit the same case as tekton.dev/v1beta1.
+func (c *TaskRunCase) WithAPIVersion(apiVersion string) *TaskRunCase {
+	c.tr.APIVersion = apiVersion
+	return c
+}
+
+// WithParam adds a string-valued param both to Spec.Params and to the
+// inline TaskSpec's declared Params.
+func (c *TaskRunCase) WithParam(name, value string) *TaskRunCase {
+	c.tr.Spec.Params = append(c.tr.Spec.Params, v1.Param{Name: name, Value: *v1.NewStructuredValues(value)})
+	c.tr.Spec.TaskSpec.Params = append(c.tr.Spec.TaskSpec.Params, v1.ParamSpec{Name: name, Type: v1.ParamTypeString})
+	return c
+}
+
+// WithWorkspaceEmptyDir declares an emptyDir workspace named name on
+// both Spec.Workspaces and the inline TaskSpec.
+func (c *TaskRunCase) WithWorkspaceEmptyDir(name string) *TaskRunCase {
+	c.tr.Spec.Workspaces = append(c.tr.Spec.Workspaces, v1.WorkspaceBinding{Name: name, EmptyDir: &corev1.EmptyDirVolumeSource{}})
+	c.tr.Spec.TaskSpec.Workspaces = append(c.tr.Spec.TaskSpec.Workspaces, v1.WorkspaceDeclaration{Name: name})
+	return c
+}
+
+// WithStepScript appends a Step running image with the given script.
+func (c *TaskRunCase) WithStepScript(name, image, script string) *TaskRunCase {
+	c.tr.Spec.TaskSpec.Steps = append(c.tr.Spec.TaskSpec.Steps, v1.Step{Name: name, Image: image, Script: script})
+	return c
+}
+
+// WithResult declares a Task-level result named name.
+func (c *TaskRunCase) WithResult(name string) *TaskRunCase {
+	c.tr.Spec.TaskSpec.Results = append(c.tr.Spec.TaskSpec.Results, v1.TaskResult{Name: name})
+	return c
+}
+
+// Object returns the underlying v1.TaskRun.
+func (c *TaskRunCase) Object() *v1.TaskRun {
+	return c.tr
+}
+
+// YAML serializes the TaskRun for submission via ProcessAndSendToTekton.
+func (c *TaskRunCase) YAML() (string, error) {
+	raw, err := yaml.Marshal(c.tr)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
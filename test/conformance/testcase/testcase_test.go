@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testcase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskRunCaseYAML(t *testing.T) {
+	tr := NewTaskRunCase("my-taskrun").
+		WithParam("string-param", "foo-string").
+		WithStepScript("check-param", "bash", "echo $(params.string-param)").
+		YAML
+
+	out, err := tr()
+	if err != nil {
+		t.Fatalf("YAML() = %v", err)
+	}
+	for _, want := range []string{"name: my-taskrun", "string-param", "foo-string", "check-param"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("YAML() output missing %q: %s", want, out)
+		}
+	}
+}
+
+func TestTaskRunCaseObject(t *testing.T) {
+	c := NewTaskRunCase("my-taskrun").WithParam("x", "y").WithResult("greeting")
+	if got := len(c.Object().Spec.Params); got != 1 {
+		t.Errorf("got %d params, want 1", got)
+	}
+	if got := len(c.Object().Spec.TaskSpec.Results); got != 1 {
+		t.Errorf("got %d results, want 1", got)
+	}
+}
@@ -0,0 +1,100 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// updateGolden regenerates .golden.yaml fixtures for table-driven
+// conformance cases instead of checking them, following Go's standard
+// -update pattern. Run with:
+//
+//	go test -tags=conformance -run ^TestStepScript$ ./test -update
+var updateGolden = flag.Bool("update", false, "update .golden.yaml files for table-driven conformance test cases")
+
+// Case is one table-driven conformance test case, loaded from a single
+// <name>.yaml file under test/conformance/testdata/<test>/. Vendors can
+// add their own variant of <test> by dropping a new YAML (and golden)
+// file into that directory, without editing Go code.
+type Case struct {
+	// Name is the file's basename without the .yaml extension.
+	Name string
+	// InputYAML is the full contents of <name>.yaml.
+	InputYAML string
+	// GoldenPath is where the case's expected-status subset lives
+	// (<name>.golden.yaml), used by tests that support -update.
+	GoldenPath string
+}
+
+// Cases loads every non-golden *.yaml file in
+// test/conformance/testdata/<test> as a Case.
+func Cases(t *testing.T, test string) []Case {
+	t.Helper()
+	dir := filepath.Join("conformance", "testdata", test)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read test case directory %q: %s", dir, err)
+	}
+	var cases []Case
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".golden.yaml") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read test case %q: %s", name, err)
+		}
+		base := strings.TrimSuffix(name, ".yaml")
+		cases = append(cases, Case{
+			Name:       base,
+			InputYAML:  string(raw),
+			GoldenPath: filepath.Join(dir, base+".golden.yaml"),
+		})
+	}
+	return cases
+}
+
+// WriteGolden overwrites c.GoldenPath with data when the test binary was
+// run with -update, and is a no-op otherwise.
+func WriteGolden(t *testing.T, c Case, data []byte) {
+	t.Helper()
+	if !*updateGolden {
+		return
+	}
+	if err := os.WriteFile(c.GoldenPath, data, 0o644); err != nil {
+		t.Fatalf("failed to update golden file %q: %s", c.GoldenPath, err)
+	}
+}
+
+// ReadGolden reads c.GoldenPath's current contents.
+func ReadGolden(t *testing.T, c Case) []byte {
+	t.Helper()
+	data, err := os.ReadFile(c.GoldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file %q: %s", c.GoldenPath, err)
+	}
+	return data
+}
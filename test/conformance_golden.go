@@ -0,0 +1,138 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// generatedPlaceholder replaces values a vendor generates per run (names,
+// UIDs, timestamps) so two runs of the same case diff identically.
+const generatedPlaceholder = "<generated>"
+
+// normalizeRun strips the fields every run populates non-deterministically
+// (generated name, UID, resourceVersion, timestamps, assigned pod) from a
+// TaskRun or PipelineRun, so the remainder can be diffed against a
+// checked-in golden file. Unrecognized types are returned unchanged.
+func normalizeRun(obj runtime.Object) runtime.Object {
+	switch o := obj.(type) {
+	case *v1.TaskRun:
+		tr := o.DeepCopy()
+		normalizeObjectMeta(&tr.ObjectMeta)
+		for i := range tr.Status.Conditions {
+			tr.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+		}
+		tr.Status.StartTime = nil
+		tr.Status.CompletionTime = nil
+		tr.Status.PodName = generatedPlaceholder
+		for i := range tr.Status.Steps {
+			tr.Status.Steps[i].ContainerState = corev1.ContainerState{}
+		}
+		return tr
+	case *v1.PipelineRun:
+		pr := o.DeepCopy()
+		normalizeObjectMeta(&pr.ObjectMeta)
+		for i := range pr.Status.Conditions {
+			pr.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+		}
+		pr.Status.StartTime = nil
+		pr.Status.CompletionTime = nil
+		for i := range pr.Status.ChildReferences {
+			pr.Status.ChildReferences[i].Name = generatedPlaceholder
+		}
+		return pr
+	default:
+		return obj
+	}
+}
+
+func normalizeObjectMeta(m *metav1.ObjectMeta) {
+	m.Name = generatedPlaceholder
+	m.UID = ""
+	m.ResourceVersion = ""
+	m.Generation = 0
+	m.CreationTimestamp = metav1.Time{}
+	m.ManagedFields = nil
+	for i := range m.OwnerReferences {
+		m.OwnerReferences[i].UID = ""
+	}
+}
+
+// clearStatusFields zeroes each named field of obj's Status struct that
+// exists, via reflection, so a test can opt a vendor out of asserting
+// fields it doesn't implement yet without hand-writing a cmp option.
+// Unknown field names are ignored.
+func clearStatusFields(obj runtime.Object, fields ...string) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	status := v.Elem().FieldByName("Status")
+	if !status.IsValid() {
+		return
+	}
+	for _, name := range fields {
+		f := status.FieldByName(name)
+		if f.IsValid() && f.CanSet() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
+
+// AssertGolden normalizes obj, marshals it to YAML, and compares it
+// against test/conformance/testdata/<name>.golden.yaml byte for byte,
+// failing with the full got and want YAML printed on mismatch. ignoreStatusFields names additional Status
+// fields to blank before comparing, for vendors that don't yet populate
+// a given field. Run with -update to (re)write the golden file from obj.
+func AssertGolden(t *testing.T, name string, obj runtime.Object, ignoreStatusFields ...string) {
+	t.Helper()
+	clearStatusFields(obj, ignoreStatusFields...)
+	normalized := normalizeRun(obj)
+
+	got, err := yaml.Marshal(normalized)
+	if err != nil {
+		t.Fatalf("failed to marshal normalized run: %s", err)
+	}
+
+	goldenPath := filepath.Join("conformance", "testdata", name+".golden.yaml")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file %q: %s", goldenPath, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file %q (run with -update to create it): %s", goldenPath, err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("output YAML for %q doesn't match golden file %q; run with -update if this change is expected.\n--- got ---\n%s\n--- want ---\n%s", name, goldenPath, got, want)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/test/helpers"
+)
+
+// APIVersions lists the API versions the conformance suite submits test
+// cases as, so a scenario can be run once per version to verify a vendor
+// accepts both during the v1beta1->v1 deprecation window and returns a
+// result convertible to the canonical v1 shape either way.
+var APIVersions = []string{"tekton.dev/v1beta1", "tekton.dev/v1"}
+
+// RunTaskRunForAPIVersions runs buildYAML and assert once per entry in
+// APIVersions, as a subtest named after the version. buildYAML receives
+// the apiVersion to embed and a unique object name; assert receives the
+// output normalized to the canonical v1 TaskRun regardless of which
+// version it was submitted as.
+func RunTaskRunForAPIVersions(t *testing.T, buildYAML func(apiVersion, name string) string, assert func(t *testing.T, resolvedTR *v1.TaskRun)) {
+	t.Helper()
+	for _, apiVersion := range APIVersions {
+		apiVersion := apiVersion
+		t.Run(apiVersion, func(t *testing.T) {
+			if !newVendorAdapter(t).SupportsAPIVersion(apiVersion) {
+				t.Skipf("vendor service does not support %s", apiVersion)
+			}
+			inputYAML := buildYAML(apiVersion, helpers.ObjectNameForTest(t))
+			outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t, apiVersion)
+			if err != nil {
+				t.Fatalf("Vendor service failed processing a %s input: %s", apiVersion, err)
+			}
+			assert(t, parse.MustParseV1TaskRun(t, outputYAML))
+		})
+	}
+}
+
+// RunPipelineRunForAPIVersions is RunTaskRunForAPIVersions for PipelineRun
+// scenarios.
+func RunPipelineRunForAPIVersions(t *testing.T, buildYAML func(apiVersion, name string) string, assert func(t *testing.T, resolvedPR *v1.PipelineRun)) {
+	t.Helper()
+	for _, apiVersion := range APIVersions {
+		apiVersion := apiVersion
+		t.Run(apiVersion, func(t *testing.T) {
+			if !newVendorAdapter(t).SupportsAPIVersion(apiVersion) {
+				t.Skipf("vendor service does not support %s", apiVersion)
+			}
+			inputYAML := buildYAML(apiVersion, helpers.ObjectNameForTest(t))
+			outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t, apiVersion)
+			if err != nil {
+				t.Fatalf("Vendor service failed processing a %s input: %s", apiVersion, err)
+			}
+			assert(t, parse.MustParseV1PipelineRun(t, outputYAML))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tektoncd/pipeline/test/conformance/report"
+)
+
+// sharedReport accumulates every RecordFeature call made by this package's
+// tests so TestMain can flush one JUnit/JSON artifact for the whole run.
+var sharedReport = report.New()
+
+// TestMain flushes sharedReport to -conformance.report (if set) after the
+// suite finishes, so a vendor running `go test -tags=conformance
+// -conformance.report=./out/report ./test` gets a self-certification
+// artifact alongside the usual pass/fail output.
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if err := report.Flush(sharedReport); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to flush conformance report: %s\n", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// RecordFeature tags t as exercising the given conformance feature (e.g.
+// "params/array", "workspaces/emptyDir") and records its outcome into
+// sharedReport on completion. Call it once near the top of a TestXxx case;
+// it always records the feature as REQUIRED. A merely recommended feature
+// is recorded by calling RecordRecommendedFeature in its place.
+func RecordFeature(t *testing.T, feature string) {
+	t.Helper()
+	report.Wrap(t, sharedReport).Section(feature, report.Required)
+}
+
+// RecordRecommendedFeature is RecordFeature for a feature that is merely
+// recommended rather than required for conformance.
+func RecordRecommendedFeature(t *testing.T, feature string) {
+	t.Helper()
+	report.Wrap(t, sharedReport).Section(feature, report.Recommended)
+}
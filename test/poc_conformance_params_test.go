@@ -0,0 +1,132 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/test/helpers"
+)
+
+// TestObjectTaskParam examines that an object-typed param round-trips
+// through a TaskRun: the keyed properties submitted in Spec.Params are
+// still present, unaltered, in the resolved TaskRun.
+func TestObjectTaskParam(t *testing.T) {
+	RecordFeature(t, "params/object")
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: %s
+spec:
+  params:
+  - name: image
+    value:
+      name: my-image
+      tag: latest
+  taskSpec:
+    params:
+    - name: image
+      type: object
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+    steps:
+    - name: echo
+      image: alpine
+      script: echo "$(params.image.name):$(params.image.tag)"
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedTR := parse.MustParseV1TaskRun(t, outputYAML)
+	if err := checkTaskRunConditionSucceeded(resolvedTR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+
+	expected := map[string]string{"name": "my-image", "tag": "latest"}
+	if len(resolvedTR.Spec.Params) != 1 {
+		t.Fatalf("Expect vendor service to provide 1 param but got: %v", len(resolvedTR.Spec.Params))
+	}
+	if d := cmp.Diff(expected, resolvedTR.Spec.Params[0].Value.ObjectVal); d != "" {
+		t.Errorf("Expect vendor service to round-trip the object param's keys unaltered: %s", d)
+	}
+}
+
+// TestStepResults examines that a step's declared results are surfaced
+// in Status.Steps[].Results, and that a later step can consume an
+// earlier step's result via $(steps.<name>.results.<result>).
+func TestStepResults(t *testing.T) {
+	RecordFeature(t, "results/step")
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: %s
+spec:
+  taskSpec:
+    steps:
+    - name: produce
+      image: alpine
+      results:
+      - name: greeting
+      script: echo -n "hello" | tee $(step.results.greeting.path)
+    - name: consume
+      image: alpine
+      script: |
+        if [ "$(steps.produce.results.greeting)" != "hello" ]; then
+          exit 1
+        fi
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedTR := parse.MustParseV1TaskRun(t, outputYAML)
+	if err := checkTaskRunConditionSucceeded(resolvedTR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+
+	for _, step := range resolvedTR.Status.Steps {
+		if step.Name != "produce" {
+			continue
+		}
+		if len(step.Results) != 1 || step.Results[0].Name != "greeting" {
+			t.Errorf("Expect vendor service to surface step \"produce\"'s result \"greeting\" in Status.Steps[].Results, got: %v", step.Results)
+		}
+	}
+
+	// Beyond the two hand-written assertions above, diff the whole
+	// normalized TaskRun against a checked-in golden file so a much wider
+	// set of fields is covered. Regenerate with:
+	//
+	//	go test -tags=conformance -run ^TestStepResults$ ./test -update
+	AssertGolden(t, "TestStepResults", resolvedTR)
+}
@@ -0,0 +1,306 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/test/helpers"
+)
+
+// This file ports the TaskRun-level conformance coverage in
+// poc_conformance_test.go to the PipelineRun level, which was previously
+// only referenced via PipelineRunInputType with no dedicated tests of its
+// own for ordering, finally, and when-expression behaviour.
+
+// TestPipelineRunResult examines that a PipelineRun surfaces results
+// produced by its PipelineTasks, and that the tasks that produced them
+// are reflected in Status.ChildReferences.
+func TestPipelineRunResult(t *testing.T) {
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    results:
+    - name: greeting
+      type: string
+      value: $(tasks.greet.results.greeting)
+    tasks:
+    - name: greet
+      taskSpec:
+        results:
+        - name: greeting
+        steps:
+        - name: greet
+          image: alpine
+          script: echo -n "hello" | tee $(results.greeting.path)
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+	if len(resolvedPR.Status.Results) != 1 || resolvedPR.Status.Results[0].Value.StringVal != "hello" {
+		t.Errorf("Expect vendor service to surface PipelineRun result \"hello\", got: %v", resolvedPR.Status.Results)
+	}
+	if len(resolvedPR.Status.ChildReferences) != 1 || resolvedPR.Status.ChildReferences[0].PipelineTaskName != "greet" {
+		t.Errorf("Expect vendor service to record a ChildReference for task \"greet\", got: %v", resolvedPR.Status.ChildReferences)
+	}
+}
+
+// TestPipelineRunTaskOrdering examines that a PipelineTask ordered via
+// runAfter only starts once its predecessor has completed, by checking
+// that the second task's first log line post-dates the first task's
+// completionTime. This catches vendors that flatten DAG execution into
+// unordered concurrent runs.
+func TestPipelineRunTaskOrdering(t *testing.T) {
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: first
+      taskSpec:
+        steps:
+        - name: first
+          image: alpine
+          script: echo "first"
+    - name: second
+      runAfter:
+      - first
+      taskSpec:
+        steps:
+        - name: second
+          image: alpine
+          script: echo "second"
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+
+	var firstCompletion *time.Time
+	childByName := map[string]string{}
+	for _, cr := range resolvedPR.Status.ChildReferences {
+		childByName[cr.PipelineTaskName] = cr.Name
+	}
+
+	adapter := newVendorAdapter(t)
+	ctx := context.Background()
+	firstTRYAML, err := adapter.GetTaskRunYAML(ctx, t, childByName["first"])
+	if err != nil {
+		t.Fatalf("failed to fetch TaskRun for \"first\": %s", err)
+	}
+	if firstTRYAML != "" {
+		firstTR := parse.MustParseV1TaskRun(t, firstTRYAML)
+		if firstTR.Status.CompletionTime != nil {
+			ct := firstTR.Status.CompletionTime.Time
+			firstCompletion = &ct
+		}
+	}
+
+	secondLogs, err := adapter.StreamLogs(ctx, t, childByName["second"])
+	if err != nil {
+		t.Fatalf("failed to stream logs for \"second\": %s", err)
+	}
+	if firstCompletion != nil && secondLogs != "" {
+		firstLine := strings.SplitN(secondLogs, "\n", 2)[0]
+		ts, logLine, ok := strings.Cut(firstLine, " ")
+		if !ok {
+			t.Fatalf("expected StreamLogs to prefix each line with an RFC3339Nano timestamp, got %q", firstLine)
+		}
+		secondLogTime, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			t.Fatalf("failed to parse timestamp %q from \"second\"'s first log line %q: %s", ts, logLine, err)
+		}
+		if secondLogTime.Before(*firstCompletion) {
+			t.Errorf("Expect the \"second\" task's first log line (%s) to post-date \"first\"'s completionTime (%s)", secondLogTime, firstCompletion)
+		}
+	}
+}
+
+// TestPipelineRunFinally examines that finally tasks run even when a
+// regular PipelineTask fails.
+func TestPipelineRunFinally(t *testing.T) {
+	expectedFailedStatus := true
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: fail
+      taskSpec:
+        steps:
+        - name: fail
+          image: alpine
+          script: exit 1
+    finally:
+    - name: cleanup
+      taskSpec:
+        steps:
+        - name: cleanup
+          image: alpine
+          script: echo "cleanup"
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t, expectedFailedStatus)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, FailureConditionStatus, "Failed"); err != nil {
+		t.Error(err)
+	}
+
+	var sawCleanup bool
+	for _, cr := range resolvedPR.Status.ChildReferences {
+		if cr.PipelineTaskName == "cleanup" {
+			sawCleanup = true
+		}
+	}
+	if !sawCleanup {
+		t.Error("Expect vendor service to run the \"cleanup\" finally task even though \"fail\" failed")
+	}
+}
+
+// TestPipelineRunWhenExpressions examines that a PipelineTask guarded by
+// a when expression that evaluates false is skipped rather than run.
+func TestPipelineRunWhenExpressions(t *testing.T) {
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: guard
+      when:
+      - input: "false"
+        operator: in
+        values: ["true"]
+      taskSpec:
+        steps:
+        - name: guard
+          image: alpine
+          script: echo "should not run"
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+
+	var sawSkipped bool
+	for _, st := range resolvedPR.Status.SkippedTasks {
+		if st.Name == "guard" {
+			sawSkipped = true
+		}
+	}
+	if !sawSkipped {
+		t.Errorf("Expect vendor service to record \"guard\" in Status.SkippedTasks, got: %v", resolvedPR.Status.SkippedTasks)
+	}
+}
+
+// TestPipelineRunResultsFromTasks examines that a PipelineRun result
+// sourced from one task can feed the param of a downstream task, and
+// that the aggregate result is surfaced at the PipelineRun level.
+func TestPipelineRunResultsFromTasks(t *testing.T) {
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    results:
+    - name: greeting
+      type: string
+      value: $(tasks.consume.results.greeting)
+    tasks:
+    - name: produce
+      taskSpec:
+        results:
+        - name: name
+        steps:
+        - name: produce
+          image: alpine
+          script: echo -n "world" | tee $(results.name.path)
+    - name: consume
+      runAfter:
+      - produce
+      params:
+      - name: name
+        value: $(tasks.produce.results.name)
+      taskSpec:
+        params:
+        - name: name
+        results:
+        - name: greeting
+        steps:
+        - name: consume
+          image: alpine
+          script: echo -n "hello, $(params.name)" | tee $(results.greeting.path)
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+	if len(resolvedPR.Status.Results) != 1 || resolvedPR.Status.Results[0].Value.StringVal != "hello, world" {
+		t.Errorf("Expect vendor service to surface PipelineRun result \"hello, world\", got: %v", resolvedPR.Status.Results)
+	}
+}
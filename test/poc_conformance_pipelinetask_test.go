@@ -0,0 +1,147 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/test/helpers"
+)
+
+// TestPipelineTaskWhenExpressions examines that a PipelineTask guarded by
+// a when clause referencing an upstream task's result is skipped once
+// that guard evaluates false: it is recorded in Status.SkippedTasks and
+// gets no entry in Status.ChildReferences.
+func TestPipelineTaskWhenExpressions(t *testing.T) {
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: decide
+      taskSpec:
+        results:
+        - name: proceed
+        steps:
+        - name: decide
+          image: alpine
+          script: echo -n "false" | tee $(results.proceed.path)
+    - name: guarded
+      runAfter:
+      - decide
+      when:
+      - input: $(tasks.decide.results.proceed)
+        operator: in
+        values: ["true"]
+      taskSpec:
+        steps:
+        - name: guarded
+          image: alpine
+          script: echo "should not run"
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+		t.Error(err)
+	}
+
+	var sawSkipped bool
+	for _, st := range resolvedPR.Status.SkippedTasks {
+		if st.Name == "guarded" {
+			sawSkipped = true
+		}
+	}
+	if !sawSkipped {
+		t.Errorf("Expect vendor service to record \"guarded\" in Status.SkippedTasks, got: %v", resolvedPR.Status.SkippedTasks)
+	}
+
+	var sawGuardedChild bool
+	for _, cr := range resolvedPR.Status.ChildReferences {
+		if cr.PipelineTaskName == "guarded" {
+			sawGuardedChild = true
+		}
+	}
+	if sawGuardedChild {
+		t.Error("Expect vendor service not to create a ChildReference for a task skipped by its when expression")
+	}
+}
+
+// TestPipelineTaskRetries examines that a PipelineTask with retries set
+// is retried the declared number of times before its PipelineRun is
+// finally marked Failed, and that each retry is recorded in the
+// Status.RetriesStatus of the child TaskRun its ChildReference names.
+func TestPipelineTaskRetries(t *testing.T) {
+	expectedFailedStatus := true
+	inputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: %s
+spec:
+  pipelineSpec:
+    tasks:
+    - name: flaky
+      retries: 2
+      taskSpec:
+        steps:
+        - name: always-fails
+          image: alpine
+          script: exit 1
+`, helpers.ObjectNameForTest(t))
+
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t, expectedFailedStatus)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+	}
+
+	resolvedPR := parse.MustParseV1PipelineRun(t, outputYAML)
+	if err := checkPipelineRunConditionSucceeded(resolvedPR.Status, FailureConditionStatus, "Failed"); err != nil {
+		t.Error(err)
+	}
+
+	for _, cr := range resolvedPR.Status.ChildReferences {
+		if cr.PipelineTaskName != "flaky" {
+			continue
+		}
+		adapter := newVendorAdapter(t)
+		trYAML, err := adapter.GetTaskRunYAML(context.Background(), t, cr.Name)
+		if err != nil {
+			t.Fatalf("failed to fetch TaskRun for \"flaky\": %s", err)
+		}
+		if trYAML == "" {
+			return
+		}
+		flakyTR := parse.MustParseV1TaskRun(t, trYAML)
+		if len(flakyTR.Status.RetriesStatus) != 2 {
+			t.Errorf("Expect vendor service to record 2 RetriesStatus entries for a task with retries: 2, got: %v", len(flakyTR.Status.RetriesStatus))
+		}
+	}
+}
@@ -17,6 +17,7 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -25,6 +26,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/conformance/testcase"
 	"github.com/tektoncd/pipeline/test/parse"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -155,123 +158,54 @@ spec:
 	}
 }
 
-func TestStepScript(t *testing.T) {
-	expectedSteps := map[string]string{
-		"noshebang":                 "Completed",
-		"node":                      "Completed",
-		"python":                    "Completed",
-		"perl":                      "Completed",
-		"params-applied":            "Completed",
-		"args-allowed":              "Completed",
-		"dollar-signs-allowed":      "Completed",
-		"bash-variable-evaluations": "Completed",
-	}
-
-	inputYAML := fmt.Sprintf(`
-apiVersion: tekton.dev/v1
-kind: TaskRun
-metadata:
-  name: %s
-spec:
-  taskSpec:
-    params:
-    - name: PARAM
-      default: param-value
-    steps:
-    - name: noshebang
-      image: ubuntu
-      script: echo "no shebang"
-    - name: node
-      image: node
-      script: |
-        #!/usr/bin/env node
-        console.log("Hello from Node!")
-    - name: python
-      image: python
-      script: |
-        #!/usr/bin/env python3
-        print("Hello from Python!")
-    - name: perl
-      image: perl:devel-bullseye
-      script: |
-        #!/usr/bin/perl
-        print "Hello from Perl!"
-    # Test that param values are replaced.
-    - name: params-applied
-      image: python
-      script: |
-        #!/usr/bin/env python3
-        v = '$(params.PARAM)'
-        if v != 'param-value':
-          print('Param values not applied')
-          print('Got: ', v)
-          exit(1)
-    # Test that args are allowed and passed to the script as expected.
-    - name: args-allowed
-      image: ubuntu
-      args: ['hello', 'world']
-      script: |
-        #!/usr/bin/env bash
-        [[ $# == 2 ]]
-        [[ $1 == "hello" ]]
-        [[ $2 == "world" ]]
-    # Test that multiple dollar signs next to each other are not replaced by Kubernetes
-    - name: dollar-signs-allowed
-      image: python
-      script: |
-        #!/usr/bin/env python3
-        if '$' != '\u0024':
-          print('single dollar signs ($) are not passed through as expected :(')
-          exit(1)
-        if '$$' != '\u0024\u0024':
-          print('double dollar signs ($$) are not passed through as expected :(')
-          exit(2)
-        if '$$$' != '\u0024\u0024\u0024':
-          print('three dollar signs ($$$) are not passed through as expected :(')
-          exit(3)
-        if '$$$$' != '\u0024\u0024\u0024\u0024':
-          print('four dollar signs ($$$$) are not passed through as expected :(')
-          exit(4)
-        print('dollar signs appear to be handled correctly! :)')
-
-    # Test that bash scripts with variable evaluations work as expected
-    - name: bash-variable-evaluations
-      image: bash:5.1.8
-      script: |
-        #!/usr/bin/env bash
-        set -xe
-        var1=var1_value
-        var2=var1
-        echo $(eval echo \$$var2) > tmpfile
-        eval_result=$(cat tmpfile)
-        if [ "$eval_result" != "var1_value" ] ; then
-          echo "unexpected eval result: $eval_result"
-          exit 1
-        fi
-`, helpers.ObjectNameForTest(t))
+// stepScriptGolden is the expected-status subset captured by each
+// TestStepScript case's <name>.golden.yaml.
+type stepScriptGolden struct {
+	Step             string `json:"step"`
+	TerminatedReason string `json:"terminatedReason"`
+}
 
-	// The execution of Pipeline CRDs that should be implemented by Vendor service
-	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
-	if err != nil {
-		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
-	}
+// TestStepScript is table-driven over test/conformance/testdata/TestStepScript:
+// every case there is a standalone TaskRun exercising one scripting
+// scenario (shebang-less scripts, multiple interpreters, param
+// substitution, args, literal dollar signs, bash variable evaluation).
+// Vendors can add their own scripting variant by dropping a new
+// <case>.yaml (and <case>.golden.yaml) into that directory.
+func TestStepScript(t *testing.T) {
+	for _, c := range Cases(t, "TestStepScript") {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			outputYAML, err := ProcessAndSendToTekton(c.InputYAML, TaskRunInputType, t)
+			if err != nil {
+				t.Fatalf("Vendor service failed processing inputYAML: %s", err)
+			}
 
-	// Parse and validate output YAML
-	resolvedTR := parse.MustParseV1TaskRun(t, outputYAML)
+			resolvedTR := parse.MustParseV1TaskRun(t, outputYAML)
+			if len(resolvedTR.Status.Steps) != 1 {
+				t.Fatalf("Expected exactly 1 step but has: %v", len(resolvedTR.Status.Steps))
+			}
+			step := resolvedTR.Status.Steps[0]
 
-	if len(resolvedTR.Status.Steps) != len(expectedSteps) {
-		t.Errorf("Expected length of steps %v but has: %v", len(expectedSteps), len(resolvedTR.Status.Steps))
-	}
+			if *updateGolden {
+				golden, err := yaml.Marshal(stepScriptGolden{Step: step.Name, TerminatedReason: step.Terminated.Reason})
+				if err != nil {
+					t.Fatalf("failed to marshal golden for %q: %s", c.Name, err)
+				}
+				WriteGolden(t, c, golden)
+				return
+			}
 
-	for _, resolvedStep := range resolvedTR.Status.Steps {
-		resolvedStepTerminatedReason := resolvedStep.Terminated.Reason
-		if expectedStepState, ok := expectedSteps[resolvedStep.Name]; ok {
-			if resolvedStepTerminatedReason != expectedStepState {
-				t.Fatalf("Expect step %s to have completed successfully but it has Termination Reason: %s", resolvedStep.Name, resolvedStepTerminatedReason)
+			var want stepScriptGolden
+			if err := yaml.Unmarshal(ReadGolden(t, c), &want); err != nil {
+				t.Fatalf("failed to parse golden file for %q: %s", c.Name, err)
 			}
-		} else {
-			t.Fatalf("Does not expect to have step: %s", resolvedStep.Name)
-		}
+			if step.Name != want.Step {
+				t.Errorf("Expect step %q but got: %q", want.Step, step.Name)
+			}
+			if step.Terminated.Reason != want.TerminatedReason {
+				t.Errorf("Expect step %s to have completed successfully but it has Termination Reason: %s", step.Name, step.Terminated.Reason)
+			}
+		})
 	}
 }
 
@@ -755,29 +689,21 @@ spec:
 }
 
 func TestStringTaskParam(t *testing.T) {
+	RecordFeature(t, "params/string")
 	stringParam := "foo-string"
 
-	inputYAML := fmt.Sprintf(`
-apiVersion: tekton.dev/v1
-kind: TaskRun
-metadata:
-  name: %s
-spec:
-  params:
-    - name: "string-param"
-      value: %s
-  taskSpec:
-    params:
-      - name: "string-param"
-        type: string
-    steps:
-      - name: "check-param"
-        image: bash
-        script: |
-          if [[ $(params.string-param) != %s ]]; then
-            exit 1
-          fi
-`, helpers.ObjectNameForTest(t), stringParam, stringParam)
+	// Built via the typed testcase builder rather than a hand-formatted
+	// YAML literal; see test/conformance/testcase.
+	inputYAML, err := testcase.NewTaskRunCase(helpers.ObjectNameForTest(t)).
+		WithParam("string-param", stringParam).
+		WithStepScript("check-param", "bash", fmt.Sprintf(`if [[ $(params.string-param) != %s ]]; then
+  exit 1
+fi
+`, stringParam)).
+		YAML()
+	if err != nil {
+		t.Fatalf("failed to build inputYAML: %s", err)
+	}
 
 	// The execution of Pipeline CRDs that should be implemented by Vendor service
 	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
@@ -993,11 +919,16 @@ spec:
 	}
 }
 
-// The goal of the Taskrun Workspace test is to verify if different Steps in the TaskRun could
-// pass data among each other.
+// TestTaskRunWorkspace verifies that different Steps in the TaskRun can
+// pass data among each other via a shared workspace. It's parameterized
+// over API versions via RunTaskRunForAPIVersions: a conformant vendor
+// must accept this scenario submitted as either tekton.dev/v1beta1 or
+// tekton.dev/v1 and return a result convertible to the canonical v1
+// shape asserted below.
 func TestTaskRunWorkspace(t *testing.T) {
-	inputYAML := fmt.Sprintf(`
-apiVersion: tekton.dev/v1
+	RunTaskRunForAPIVersions(t, func(apiVersion, name string) string {
+		return fmt.Sprintf(`
+apiVersion: %s
 kind: TaskRun
 metadata:
   name: %s
@@ -1024,32 +955,24 @@ spec:
         fi
     workspaces:
     - name: custom-workspace
-`, helpers.ObjectNameForTest(t))
-
-	// The execution of Pipeline CRDs that should be implemented by Vendor service
-	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
-	if err != nil {
-		t.Fatalf("Vendor service failed processing inputYAML: %s", err)
-	}
-
-	// Parse and validate output YAML
-	resolvedTR := parse.MustParseV1TaskRun(t, outputYAML)
-
-	if err := checkTaskRunConditionSucceeded(resolvedTR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
-		t.Error(err)
-	}
+`, apiVersion, name)
+	}, func(t *testing.T, resolvedTR *v1.TaskRun) {
+		if err := checkTaskRunConditionSucceeded(resolvedTR.Status, SucceedConditionStatus, "Succeeded"); err != nil {
+			t.Error(err)
+		}
 
-	if len(resolvedTR.Spec.Workspaces) != 1 {
-		t.Errorf("Expect vendor service to provide 1 Workspace but it has: %v", len(resolvedTR.Spec.Workspaces))
-	}
+		if len(resolvedTR.Spec.Workspaces) != 1 {
+			t.Errorf("Expect vendor service to provide 1 Workspace but it has: %v", len(resolvedTR.Spec.Workspaces))
+		}
 
-	if resolvedTR.Spec.Workspaces[0].Name != "custom-workspace" {
-		t.Errorf("Expect vendor service to provide Workspace 'custom-workspace' but it has: %s", resolvedTR.Spec.Workspaces[0].Name)
-	}
+		if resolvedTR.Spec.Workspaces[0].Name != "custom-workspace" {
+			t.Errorf("Expect vendor service to provide Workspace 'custom-workspace' but it has: %s", resolvedTR.Spec.Workspaces[0].Name)
+		}
 
-	if resolvedTR.Status.TaskSpec.Workspaces[0].Name != "custom-workspace" {
-		t.Errorf("Expect vendor service to provide Workspace 'custom-workspace' in TaskRun.Status.TaskSpec but it has: %s", resolvedTR.Spec.Workspaces[0].Name)
-	}
+		if resolvedTR.Status.TaskSpec.Workspaces[0].Name != "custom-workspace" {
+			t.Errorf("Expect vendor service to provide Workspace 'custom-workspace' in TaskRun.Status.TaskSpec but it has: %s", resolvedTR.Spec.Workspaces[0].Name)
+		}
+	})
 }
 
 // TestTaskRunTimeout examines the Timeout behaviour for
@@ -1586,15 +1509,30 @@ spec:
 		}
 	}
 
+	// ChildReferences being populated with the right names isn't enough on
+	// its own: a conformant vendor must also leave the underlying TaskRuns
+	// in a consistent state, owned by and agreeing with the PipelineRun.
+	adapter := newVendorAdapter(t)
+	childTRs, err := adapter.GetChildTaskRuns(context.Background(), t, resolvedPR)
+	if err != nil {
+		t.Fatalf("failed to fetch child TaskRuns: %s", err)
+	}
+	if err := checkChildRefsConsistent(resolvedPR, childTRs); err != nil {
+		t.Error(err)
+	}
 }
 
 // ProcessAndSendToTekton takes in vanilla Tekton PipelineRun and TaskRun, waits for the object to succeed and outputs the final PipelineRun and TaskRun with status.
-// The parameters are inputYAML and its Primitive type {PipelineRun, TaskRun}
+// The parameters are inputYAML and its Primitive type {PipelineRun, TaskRun}.
+// An optional string customInput selects the API version to submit as
+// (one of APIVersions, see conformance_versions.go); it defaults to
+// "tekton.dev/v1" if omitted.
 // And the return values will be the output YAML string and errors.
 func ProcessAndSendToTekton(inputYAML, primitiveType string, customInputs ...interface{}) (string, error) {
 	// Handle customInputs
 	var t *testing.T
 	var expectRunToFail bool
+	apiVersion := "tekton.dev/v1"
 	for _, customInput := range customInputs {
 		if ci, ok := customInput.(*testing.T); ok {
 			t = ci
@@ -1602,23 +1540,35 @@ func ProcessAndSendToTekton(inputYAML, primitiveType string, customInputs ...int
 		if ci, ok := customInput.(bool); ok {
 			expectRunToFail = ci
 		}
+		if ci, ok := customInput.(string); ok {
+			apiVersion = ci
+		}
 	}
 
-	return mockTektonPipelineController(t, inputYAML, primitiveType, expectRunToFail)
+	return mockTektonPipelineController(t, inputYAML, primitiveType, expectRunToFail, apiVersion)
 }
 
 // mockTektonPipelineController fakes the behaviour of a vendor service by utilizing the Tekton test infrastructure.
 // For the POC, it uses the Tetkon clients to Create, Wait for and Get the expected TaskRun.
-func mockTektonPipelineController(t *testing.T, inputYAML, primitiveType string, expectRunToFail bool) (string, error) {
+func mockTektonPipelineController(t *testing.T, inputYAML, primitiveType string, expectRunToFail bool, apiVersion string) (string, error) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	// cancel must outlive the teardown below, which still needs a live
+	// ctx; register it first so, per t.Cleanup's LIFO order, it runs
+	// after the teardown Cleanup registered below.
+	t.Cleanup(cancel)
 
 	c, namespace := setup(ctx, t)
 	knativetest.CleanupOnInterrupt(func() { tearDown(ctx, t, c, namespace) }, t.Logf)
-	defer tearDown(ctx, t, c, namespace)
+	// Tear the namespace down when t itself finishes, not when this call
+	// returns, so TektonAdapter's Get* methods (registerTestClients) can
+	// still fetch resources from it later in the same test - e.g. a
+	// PipelineRun's child TaskRuns, which this function never created
+	// directly and so never had a chance to hand back.
+	t.Cleanup(func() { tearDown(ctx, t, c, namespace) })
+	registerTestClients(t, c, namespace)
 
-	mvs := MockVendorSerivce{cs: c}
+	mvs := MockVendorSerivce{cs: c, apiVersion: apiVersion}
 
 	var outputYAML []byte
 	switch primitiveType {
@@ -1678,23 +1628,80 @@ type VendorService interface {
 
 type MockVendorSerivce struct {
 	cs *clients
+	// apiVersion is the tekton.dev API version runs are submitted as
+	// (see APIVersions in conformance_versions.go), defaulting to
+	// "tekton.dev/v1" on the zero value.
+	apiVersion string
+}
+
+// version returns mvs.apiVersion, defaulting to v1Version so existing
+// callers that never set it keep behaving exactly as before.
+func (mvs MockVendorSerivce) version() string {
+	if mvs.apiVersion == "" {
+		return v1Version
+	}
+	return mvs.apiVersion
 }
 
 // CreateTaskRun parses the inputYAML to a TaskRun and creates the TaskRun via TaskRunClient
 func (mvs MockVendorSerivce) CreateTaskRun(ctx context.Context, inputYAML string) (*v1.TaskRun, error) {
-	var tr v1.TaskRun
-	if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &tr); err != nil {
-		return nil, fmt.Errorf("must parse YAML (%s): %v", inputYAML, err)
+	tr, err := mvs.decodeTaskRun(inputYAML)
+	if err != nil {
+		return nil, err
 	}
 
 	var trCreated *v1.TaskRun
-	trCreated, err := mvs.cs.V1TaskRunClient.Create(ctx, &tr, metav1.CreateOptions{})
+	trCreated, err = mvs.cs.V1TaskRunClient.Create(ctx, tr, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TaskRun `%v`: %w", tr, err)
 	}
 	return trCreated, nil
 }
 
+// decodeTaskRun parses inputYAML as a v1.TaskRun, honoring mvs.version():
+// a "tekton.dev/v1" input decodes straight into v1.TaskRun as before, but a
+// "tekton.dev/v1beta1" input is decoded into a v1beta1.TaskRun first (so a
+// shape that doesn't actually match v1beta1's schema is caught here,
+// instead of being silently parsed as a v1.TaskRun regardless of its
+// declared apiVersion) and then carried over into v1 field-for-field via a
+// JSON round-trip. This checkout's clients struct only carries a
+// V1TaskRunClient - there's no V1beta1TaskRunClient to submit a
+// v1beta1.TaskRun through directly - and has no TaskRun-level
+// ConvertTo/ConvertFrom the way PipelineRef/TaskRef do (see
+// pipelineref_conversion.go/taskref_conversion.go), so the JSON round-trip
+// stands in for a real typed conversion; it's exact for every field common
+// to both versions and silently drops any v1beta1-only field, which is an
+// acceptable approximation for a mock vendor service but not for a real one.
+func (mvs MockVendorSerivce) decodeTaskRun(inputYAML string) (*v1.TaskRun, error) {
+	if mvs.version() == "tekton.dev/v1beta1" {
+		var trBeta v1beta1.TaskRun
+		if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &trBeta); err != nil {
+			return nil, fmt.Errorf("must parse YAML (%s) as a v1beta1 TaskRun: %v", inputYAML, err)
+		}
+		var tr v1.TaskRun
+		if err := convertViaJSON(&trBeta, &tr); err != nil {
+			return nil, fmt.Errorf("failed to convert v1beta1 TaskRun %q to v1: %w", trBeta.Name, err)
+		}
+		return &tr, nil
+	}
+	var tr v1.TaskRun
+	if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &tr); err != nil {
+		return nil, fmt.Errorf("must parse YAML (%s): %v", inputYAML, err)
+	}
+	return &tr, nil
+}
+
+// convertViaJSON copies from's fields into to by marshaling and
+// unmarshaling through JSON, relying on the two types sharing the same
+// field names and json tags for every field they have in common.
+func convertViaJSON(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
+
 // CreateTaskRun waits for the TaskRun to get done according to the expected Condition Accessor function
 func (mvs MockVendorSerivce) WaitForTaskRun(ctx context.Context, name string, expectRunToFail bool) error {
 	var caf ConditionAccessorFn
@@ -1702,7 +1709,7 @@ func (mvs MockVendorSerivce) WaitForTaskRun(ctx context.Context, name string, ex
 	if expectRunToFail {
 		caf = Failed(name)
 	}
-	if err := WaitForTaskRunState(ctx, mvs.cs, name, caf, "WaitTaskRunDone", v1Version); err != nil {
+	if err := WaitForTaskRunState(ctx, mvs.cs, name, caf, "WaitTaskRunDone", mvs.version()); err != nil {
 		return fmt.Errorf("error waiting for TaskRun to finish: %s", err)
 	}
 	return nil
@@ -1718,26 +1725,48 @@ func (mvs MockVendorSerivce) GetTaskRun(ctx context.Context, name string) (*v1.T
 }
 
 func (mvs MockVendorSerivce) CreatePipelineRun(ctx context.Context, inputYAML string) (*v1.PipelineRun, error) {
-	var pr v1.PipelineRun
-	if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &pr); err != nil {
-		return nil, fmt.Errorf("must parse YAML (%s): %v", inputYAML, err)
+	pr, err := mvs.decodePipelineRun(inputYAML)
+	if err != nil {
+		return nil, err
 	}
 
 	var prCreated *v1.PipelineRun
-	prCreated, err := mvs.cs.V1PipelineRunClient.Create(ctx, &pr, metav1.CreateOptions{})
+	prCreated, err = mvs.cs.V1PipelineRunClient.Create(ctx, pr, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create PipelineRun `%v`: %w", pr, err)
 	}
 	return prCreated, nil
 }
 
+// decodePipelineRun is decodeTaskRun's PipelineRun counterpart; see its
+// doc comment for why a v1beta1 input goes through v1beta1.PipelineRun and
+// a JSON round-trip rather than a dedicated V1beta1PipelineRunClient.
+func (mvs MockVendorSerivce) decodePipelineRun(inputYAML string) (*v1.PipelineRun, error) {
+	if mvs.version() == "tekton.dev/v1beta1" {
+		var prBeta v1beta1.PipelineRun
+		if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &prBeta); err != nil {
+			return nil, fmt.Errorf("must parse YAML (%s) as a v1beta1 PipelineRun: %v", inputYAML, err)
+		}
+		var pr v1.PipelineRun
+		if err := convertViaJSON(&prBeta, &pr); err != nil {
+			return nil, fmt.Errorf("failed to convert v1beta1 PipelineRun %q to v1: %w", prBeta.Name, err)
+		}
+		return &pr, nil
+	}
+	var pr v1.PipelineRun
+	if _, _, err := scheme.Codecs.UniversalDeserializer().Decode([]byte(inputYAML), nil, &pr); err != nil {
+		return nil, fmt.Errorf("must parse YAML (%s): %v", inputYAML, err)
+	}
+	return &pr, nil
+}
+
 func (mvs MockVendorSerivce) WaitForPipelineRun(ctx context.Context, name string, expectRunToFail bool) error {
 	var caf ConditionAccessorFn
 	caf = Succeed(name)
 	if expectRunToFail {
 		caf = Failed(name)
 	}
-	if err := WaitForPipelineRunState(ctx, mvs.cs, name, timeout, caf, "WaitPipelineRunDone", v1Version); err != nil {
+	if err := WaitForPipelineRunState(ctx, mvs.cs, name, timeout, caf, "WaitPipelineRunDone", mvs.version()); err != nil {
 		return fmt.Errorf("error waiting for PipelineRun to finish: %s", err)
 	}
 	return nil
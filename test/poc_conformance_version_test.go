@@ -0,0 +1,94 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/test/helpers"
+)
+
+// ignoreServerPopulatedTaskRunFields lists the TaskRunStatus fields a
+// vendor is expected to populate independently on each API version (e.g.
+// timestamps, generated names) and which a round-trip diff must
+// therefore ignore to avoid false failures.
+var ignoreServerPopulatedTaskRunFields = cmpopts.IgnoreFields(v1.TaskRunStatusFields{}, "StartTime", "CompletionTime", "PodName")
+
+// TestAPIVersionRoundTrip examines that a conformant vendor serving the
+// v1 API also accepts v1beta1 TaskRuns, and that the object retrieved
+// back is field-equivalent whichever version it's fetched as. Vendors
+// implementing v1 conformance are commonly still reached by v1beta1
+// typed clients (e.g. older Tekton CLI versions) during the v1beta1->v1
+// deprecation window, and must round-trip losslessly.
+func TestAPIVersionRoundTrip(t *testing.T) {
+	v1beta1InputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1beta1
+kind: TaskRun
+metadata:
+  name: %s
+spec:
+  taskSpec:
+    results:
+    - name: greeting
+    steps:
+    - name: greet
+      image: alpine
+      script: echo -n "hello" | tee $(results.greeting.path)
+`, helpers.ObjectNameForTest(t))
+
+	v1beta1OutputYAML, err := ProcessAndSendToTekton(v1beta1InputYAML, TaskRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing a v1beta1 input: %s", err)
+	}
+	fromV1beta1 := parse.MustParseV1TaskRun(t, v1beta1OutputYAML)
+
+	v1InputYAML := fmt.Sprintf(`
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: %s
+spec:
+  taskSpec:
+    results:
+    - name: greeting
+    steps:
+    - name: greet
+      image: alpine
+      script: echo -n "hello" | tee $(results.greeting.path)
+`, helpers.ObjectNameForTest(t))
+
+	v1OutputYAML, err := ProcessAndSendToTekton(v1InputYAML, TaskRunInputType, t)
+	if err != nil {
+		t.Fatalf("Vendor service failed processing a v1 input: %s", err)
+	}
+	fromV1 := parse.MustParseV1TaskRun(t, v1OutputYAML)
+
+	if d := cmp.Diff(fromV1.Status.Results, fromV1beta1.Status.Results, ignoreServerPopulatedTaskRunFields); d != "" {
+		t.Errorf("v1beta1-submitted TaskRun results don't match the v1-submitted equivalent once fetched as v1 (-v1 +v1beta1): %s", d)
+	}
+	if d := cmp.Diff(fromV1.Status.Steps, fromV1beta1.Status.Steps, ignoreServerPopulatedTaskRunFields, cmpopts.IgnoreFields(v1.StepState{}, "ContainerState")); d != "" {
+		t.Errorf("v1beta1-submitted TaskRun step states don't match the v1-submitted equivalent once fetched as v1 (-v1 +v1beta1): %s", d)
+	}
+}
@@ -0,0 +1,377 @@
+//go:build conformance
+// +build conformance
+
+/*
+Copyright 2024 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/parse"
+	"knative.dev/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// VendorAdapter is the seam between the conformance suite and the service
+// under test. The suite was originally written against a single
+// package-level ProcessAndSendToTekton function, which bakes in one
+// execution path (an in-process Tekton controller). VendorAdapter pulls
+// that execution path behind an interface so a non-Tekton vendor can
+// implement conformance with a small Go adapter, or an out-of-process
+// shim invoked over exec/HTTP, instead of forking the suite.
+type VendorAdapter interface {
+	// CreateTaskRun submits a TaskRun (given as YAML) to the vendor
+	// service and returns its generated name.
+	CreateTaskRun(ctx context.Context, t *testing.T, inputYAML string) (string, error)
+	// CreatePipelineRun submits a PipelineRun (given as YAML) to the
+	// vendor service and returns its generated name.
+	CreatePipelineRun(ctx context.Context, t *testing.T, inputYAML string) (string, error)
+	// WaitForCompletion blocks until the named run finishes, returning an
+	// error if it didn't reach the expected success/failure condition.
+	WaitForCompletion(ctx context.Context, t *testing.T, name string, expectFailure bool) error
+	// GetTaskRunYAML returns the final state of a completed TaskRun.
+	GetTaskRunYAML(ctx context.Context, t *testing.T, name string) (string, error)
+	// GetPipelineRunYAML returns the final state of a completed PipelineRun.
+	GetPipelineRunYAML(ctx context.Context, t *testing.T, name string) (string, error)
+	// StreamLogs returns the combined step/task log output for a run, one
+	// line per log line, so conformance cases can assert on log ordering
+	// guarantees. Each line must be prefixed with an RFC3339Nano
+	// timestamp followed by a single space, mirroring `kubectl logs
+	// --timestamps=true`, so callers can compare actual log times rather
+	// than the log text itself. An adapter that can't attribute a real
+	// time to each line should return "", nil rather than fabricate one.
+	StreamLogs(ctx context.Context, t *testing.T, name string) (string, error)
+	// GetChildTaskRuns resolves every entry in pr.Status.ChildReferences to
+	// the TaskRun it names, so a conformance case can assert on the
+	// children's own status rather than trusting the parent's summary of
+	// them. Entries whose Kind isn't "TaskRun" (e.g. a Run/CustomRun) are
+	// skipped.
+	GetChildTaskRuns(ctx context.Context, t *testing.T, pr *v1.PipelineRun) ([]*v1.TaskRun, error)
+	// SupportsAPIVersion reports whether the vendor service accepts runs
+	// submitted as apiVersion (one of APIVersions, see
+	// conformance_versions.go). RunTaskRunForAPIVersions and
+	// RunPipelineRunForAPIVersions skip a version a vendor doesn't support
+	// rather than failing it.
+	SupportsAPIVersion(apiVersion string) bool
+}
+
+// conformanceAdapterEnvVar selects which VendorAdapter implementation
+// backs the suite by name, with no further configuration. Unset, or set
+// to "tekton", selects TektonAdapter; other values must name a driver
+// registered via RegisterVendorDriver. A driver that needs its own
+// configuration should be selected via CONFORMANCE_VENDOR_CONFIG instead.
+const conformanceAdapterEnvVar = "CONFORMANCE_ADAPTER"
+
+// TektonAdapter is the default VendorAdapter. It mocks a conformant
+// vendor service by driving the TaskRun/PipelineRun lifecycle directly
+// through Tekton's own reconcilers, the same way the original
+// ProcessAndSendToTekton helper did, and delegates to it so existing
+// call sites keep working while they're migrated onto the adapter
+// interface.
+type TektonAdapter struct{}
+
+// CreateTaskRun implements VendorAdapter. ProcessAndSendToTekton already
+// waits for completion and registers this test's clients (see
+// registerTestClients), so this only needs to hand back the generated
+// name, per the VendorAdapter contract.
+func (TektonAdapter) CreateTaskRun(ctx context.Context, t *testing.T, inputYAML string) (string, error) {
+	t.Helper()
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, TaskRunInputType, t)
+	if err != nil {
+		return "", err
+	}
+	return parse.MustParseV1TaskRun(t, outputYAML).Name, nil
+}
+
+// CreatePipelineRun implements VendorAdapter. See CreateTaskRun.
+func (TektonAdapter) CreatePipelineRun(ctx context.Context, t *testing.T, inputYAML string) (string, error) {
+	t.Helper()
+	outputYAML, err := ProcessAndSendToTekton(inputYAML, PipelineRunInputType, t)
+	if err != nil {
+		return "", err
+	}
+	return parse.MustParseV1PipelineRun(t, outputYAML).Name, nil
+}
+
+// WaitForCompletion implements VendorAdapter. ProcessAndSendToTekton
+// already blocks until completion as part of Create*, so this is a no-op
+// for TektonAdapter; out-of-process adapters will generally need it.
+func (TektonAdapter) WaitForCompletion(ctx context.Context, t *testing.T, name string, expectFailure bool) error {
+	t.Helper()
+	return nil
+}
+
+// GetTaskRunYAML implements VendorAdapter by fetching name through the
+// clients mockTektonPipelineController registered for t via
+// registerTestClients. Those clients' namespace stays live for the rest
+// of t's run (see the t.Cleanup in mockTektonPipelineController), so this
+// reaches real TaskRuns Create* itself never returned, e.g. PipelineRun
+// children.
+func (TektonAdapter) GetTaskRunYAML(ctx context.Context, t *testing.T, name string) (string, error) {
+	t.Helper()
+	entry, ok := lookupTestClients(t)
+	if !ok {
+		return "", fmt.Errorf("no Tekton clients registered for this test; call CreateTaskRun or CreatePipelineRun first")
+	}
+	tr, err := entry.cs.V1TaskRunClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get TaskRun %q: %w", name, err)
+	}
+	out, err := yaml.Marshal(tr)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal TaskRun %q: %w", name, err)
+	}
+	return string(out), nil
+}
+
+// GetPipelineRunYAML implements VendorAdapter. See GetTaskRunYAML.
+func (TektonAdapter) GetPipelineRunYAML(ctx context.Context, t *testing.T, name string) (string, error) {
+	t.Helper()
+	entry, ok := lookupTestClients(t)
+	if !ok {
+		return "", fmt.Errorf("no Tekton clients registered for this test; call CreateTaskRun or CreatePipelineRun first")
+	}
+	pr, err := entry.cs.V1PipelineRunClient.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get PipelineRun %q: %w", name, err)
+	}
+	out, err := yaml.Marshal(pr)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PipelineRun %q: %w", name, err)
+	}
+	return string(out), nil
+}
+
+// StreamLogs implements VendorAdapter. This checkout has no Pod/container
+// runtime behind mockTektonPipelineController - TaskRuns here are driven
+// straight through to a terminal status without ever scheduling a real
+// Pod - so there are no real container logs, timestamped or otherwise,
+// for TektonAdapter to capture. It returns "", nil rather than fabricate
+// timestamps it can't back with an actual log stream; callers must treat
+// an empty result as "this adapter can't verify log ordering" rather than
+// "the run produced no logs".
+func (TektonAdapter) StreamLogs(ctx context.Context, t *testing.T, name string) (string, error) {
+	t.Helper()
+	return "", nil
+}
+
+// SupportsAPIVersion implements VendorAdapter. TektonAdapter, being Tekton
+// itself, accepts every version the conformance suite knows about.
+func (TektonAdapter) SupportsAPIVersion(apiVersion string) bool {
+	return true
+}
+
+// GetChildTaskRuns implements VendorAdapter by fetching each TaskRun
+// ChildReference individually through GetTaskRunYAML.
+func (a TektonAdapter) GetChildTaskRuns(ctx context.Context, t *testing.T, pr *v1.PipelineRun) ([]*v1.TaskRun, error) {
+	t.Helper()
+	var trs []*v1.TaskRun
+	for _, cr := range pr.Status.ChildReferences {
+		if cr.Kind != "TaskRun" {
+			continue
+		}
+		trYAML, err := a.GetTaskRunYAML(ctx, t, cr.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch child TaskRun %q: %w", cr.Name, err)
+		}
+		if trYAML == "" {
+			continue
+		}
+		trs = append(trs, parse.MustParseV1TaskRun(t, trYAML))
+	}
+	return trs, nil
+}
+
+// checkChildRefsConsistent validates that every TaskRun ChildReference on
+// pr resolved to a real TaskRun in trs, that each child's owner reference
+// points back to pr, and that each child's Succeeded condition agrees with
+// pr's own. It returns the first inconsistency found, or nil.
+func checkChildRefsConsistent(pr *v1.PipelineRun, trs []*v1.TaskRun) error {
+	byName := make(map[string]*v1.TaskRun, len(trs))
+	for _, tr := range trs {
+		byName[tr.Name] = tr
+	}
+
+	parentCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
+
+	for _, cr := range pr.Status.ChildReferences {
+		if cr.Kind != "TaskRun" {
+			continue
+		}
+		tr, ok := byName[cr.Name]
+		if !ok {
+			return fmt.Errorf("ChildReference %q does not resolve to a fetched TaskRun", cr.Name)
+		}
+
+		var owned bool
+		for _, ref := range tr.OwnerReferences {
+			if ref.Name == pr.Name && ref.Kind == "PipelineRun" {
+				owned = true
+			}
+		}
+		if !owned {
+			return fmt.Errorf("TaskRun %q has no owner reference back to PipelineRun %q", tr.Name, pr.Name)
+		}
+
+		childCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
+		if parentCondition != nil && childCondition != nil && childCondition.Status != parentCondition.Status {
+			return fmt.Errorf("TaskRun %q has Succeeded=%s but PipelineRun %q has Succeeded=%s", tr.Name, childCondition.Status, pr.Name, parentCondition.Status)
+		}
+	}
+	return nil
+}
+
+// conformanceVendorConfigEnvVar names a YAML file describing how to reach a
+// non-mock vendor service: which driver to use (a name registered via
+// RegisterVendorDriver) and that driver's own configuration, e.g. a
+// kubeconfig context + namespace for a real cluster, an HTTP endpoint for a
+// remote "submit YAML, poll for result" service, or the path to a vendor
+// CLI for an exec-based driver. CONFORMANCE_ADAPTER remains a shorthand for
+// selecting a registered driver with no configuration of its own.
+const conformanceVendorConfigEnvVar = "CONFORMANCE_VENDOR_CONFIG"
+
+// VendorDriverFactory builds a VendorAdapter from a driver's own
+// configuration blob, as passed to RegisterVendorDriver.
+type VendorDriverFactory func(cfg []byte) (VendorAdapter, error)
+
+// vendorDrivers holds every driver registered via RegisterVendorDriver,
+// keyed by name. TektonAdapter registers itself as "tekton" in init.
+var vendorDrivers = map[string]VendorDriverFactory{}
+
+// RegisterVendorDriver makes a VendorAdapter implementation selectable by
+// name, either via CONFORMANCE_ADAPTER=name (no config) or via the driver
+// field of a CONFORMANCE_VENDOR_CONFIG YAML file (with config). Call it
+// from an init func in the package providing the driver.
+func RegisterVendorDriver(name string, factory VendorDriverFactory) {
+	vendorDrivers[name] = factory
+}
+
+func init() {
+	RegisterVendorDriver("tekton", func(cfg []byte) (VendorAdapter, error) {
+		return TektonAdapter{}, nil
+	})
+}
+
+// vendorConfigFile is the schema of a CONFORMANCE_VENDOR_CONFIG YAML file:
+// which registered driver to use, and that driver's own configuration,
+// passed through to its factory unparsed.
+type vendorConfigFile struct {
+	Driver string    `json:"driver"`
+	Config yamlValue `json:"config"`
+}
+
+// yamlValue defers decoding of the "config" block so each driver's factory
+// can interpret it in whatever shape it needs.
+type yamlValue struct {
+	raw []byte
+}
+
+func (v *yamlValue) UnmarshalJSON(data []byte) error {
+	v.raw = append([]byte(nil), data...)
+	return nil
+}
+
+// newVendorAdapter returns the VendorAdapter selected by
+// CONFORMANCE_VENDOR_CONFIG (a driver + its config, see vendorConfigFile)
+// or, failing that, CONFORMANCE_ADAPTER (a bare driver name, no config),
+// defaulting to the built-in "tekton" driver. Tests that want to exercise
+// a non-Tekton vendor should call this instead of invoking
+// ProcessAndSendToTekton directly.
+func newVendorAdapter(t *testing.T) VendorAdapter {
+	t.Helper()
+
+	if configPath := os.Getenv(conformanceVendorConfigEnvVar); configPath != "" {
+		raw, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatalf("failed to read %s=%q: %s", conformanceVendorConfigEnvVar, configPath, err)
+		}
+		var cfg vendorConfigFile
+		if err := yaml.Unmarshal(raw, &cfg); err != nil {
+			t.Fatalf("failed to parse %s=%q: %s", conformanceVendorConfigEnvVar, configPath, err)
+		}
+		factory, ok := vendorDrivers[cfg.Driver]
+		if !ok {
+			t.Fatalf("%s=%q selects driver %q, which has no RegisterVendorDriver entry", conformanceVendorConfigEnvVar, configPath, cfg.Driver)
+		}
+		adapter, err := factory(cfg.Config.raw)
+		if err != nil {
+			t.Fatalf("driver %q failed to initialize from %q: %s", cfg.Driver, configPath, err)
+		}
+		return adapter
+	}
+
+	name := os.Getenv(conformanceAdapterEnvVar)
+	if name == "" {
+		name = "tekton"
+	}
+	factory, ok := vendorDrivers[name]
+	if !ok {
+		t.Fatalf("%s=%q has no registered VendorAdapter; only \"tekton\" is built in today", conformanceAdapterEnvVar, name)
+		return nil
+	}
+	adapter, err := factory(nil)
+	if err != nil {
+		t.Fatalf("driver %q failed to initialize: %s", name, err)
+	}
+	return adapter
+}
+
+// testClientsEntry is the Tekton clients + namespace mockTektonPipelineController
+// set up for a given test, so TektonAdapter's Get* methods can fetch
+// resources by name after Create* returns instead of only ever seeing
+// what Create* itself handed back.
+type testClientsEntry struct {
+	cs        *clients
+	namespace string
+}
+
+var (
+	testClientsMu sync.Mutex
+	testClients   = map[*testing.T]testClientsEntry{}
+)
+
+// registerTestClients records cs/namespace under t so TektonAdapter's
+// Get* methods can look them up later in the same test, and arranges for
+// the entry to be removed via t.Cleanup once the test itself tears its
+// namespace down.
+func registerTestClients(t *testing.T, cs *clients, namespace string) {
+	t.Helper()
+	testClientsMu.Lock()
+	testClients[t] = testClientsEntry{cs: cs, namespace: namespace}
+	testClientsMu.Unlock()
+	t.Cleanup(func() {
+		testClientsMu.Lock()
+		delete(testClients, t)
+		testClientsMu.Unlock()
+	})
+}
+
+// lookupTestClients returns the clients/namespace registerTestClients
+// recorded for t, if any.
+func lookupTestClients(t *testing.T) (testClientsEntry, bool) {
+	testClientsMu.Lock()
+	defer testClientsMu.Unlock()
+	e, ok := testClients[t]
+	return e, ok
+}